@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Modos de POST /api/matches/batch
+const (
+	batchAtomic     = "atomic"      // todos los partidos o ninguno
+	batchBestEffort = "best-effort" // crea los válidos e informa el resto
+)
+
+// maxBatchSize es la cantidad máxima de partidos por lote; una jornada son 10
+const maxBatchSize = 100
+
+// maxBatchBytes limita el cuerpo del lote; alcanza de sobra para maxBatchSize
+// partidos
+const maxBatchBytes = 256 << 10
+
+// batchBodyLimit aplica maxBatchBytes también al leer el cuerpo para
+// Idempotency-Key, con el mismo problema que el handler
+var batchBodyLimit = bodyLimit{Bytes: maxBatchBytes, Code: codeBatchTooLarge, Detail: "detail.batch_body_too_large"}
+
+// matchRequest es el cuerpo de un partido nuevo o reemplazado
+type matchRequest struct {
+	matchTeams
+	MatchDate string `json:"matchDate" binding:"required"`
+}
+
+// input valida r como lo hace POST /api/matches, salvo los equipos que se
+// resuelven después con teamIDs. Los campos de los errores llevan el prefijo
+// indicado, por ejemplo "[3].matchDate".
+func (r matchRequest) input(c *gin.Context, prefix string) (MatchInput, []FieldError) {
+	var fields []FieldError
+	var validationErrs validator.ValidationErrors
+	if err := binding.Validator.ValidateStruct(&r); errors.As(err, &validationErrs) {
+		for _, fe := range validationErrs {
+			field := validationFieldError(c, fe)
+			field.Field = prefix + field.Field
+			fields = append(fields, field)
+		}
+	}
+	date, err := time.Parse(time.DateOnly, r.MatchDate)
+	if err != nil && r.MatchDate != "" {
+		fields = append(fields, FieldError{Field: prefix + "matchDate", Code: codeInvalidDate, Message: tr(c, "field.date_format")})
+	}
+	return MatchInput{MatchDate: date}, fields
+}
+
+// BatchResult es el resultado de un partido del lote
+type BatchResult struct {
+	Index  int          `json:"index"`
+	Status int          `json:"status"`
+	ID     int          `json:"id,omitempty"`
+	Code   string       `json:"code,omitempty"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// BatchResponse es la respuesta de la creación en lote
+type BatchResponse struct {
+	Mode    string        `json:"mode"`
+	Created int           `json:"created"`
+	Failed  int           `json:"failed"`
+	IDs     []int         `json:"ids"`
+	Results []BatchResult `json:"results"`
+}
+
+// createMatchBatch godoc
+// @Summary Crear partidos en lote
+// @Description Crea varios partidos, por ejemplo una jornada completa. Todos se validan antes de crear ninguno.
+// @Description En modo atomic (por defecto) se insertan en una transacción: si alguno es inválido no se crea ninguno y la respuesta es 400.
+// @Description En modo best-effort se crean los válidos y cada resultado indica su estado; la respuesta es 207 si alguno falló.
+// @Description Los equipos se indican como en POST /api/matches; un equipo inexistente hace fallar el partido con UNKNOWN_TEAM (422).
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param mode query string false "Modo del lote" Enums(atomic, best-effort)
+// @Param matches body []matchRequest true "Partidos a crear (máximo 100 y 256 KiB)"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 201 {object} BatchResponse
+// @Success 207 {object} BatchResponse
+// @Failure 400 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/batch [post]
+func (a *app) createMatchBatch(c *gin.Context) {
+	mode := c.DefaultQuery("mode", batchAtomic)
+	if mode != batchAtomic && mode != batchBestEffort {
+		respondProblem(c, codeInvalidQuery, tr(c, "detail.batch_mode"),
+			FieldError{Field: "mode", Code: fieldInvalidValue, Message: tr(c, "validation.oneof", batchAtomic+" "+batchBestEffort)})
+		return
+	}
+
+	var items []matchRequest
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBytes)
+	if err := json.NewDecoder(body).Decode(&items); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondProblem(c, codeBatchTooLarge, tr(c, "detail.batch_body_too_large", tooLarge.Limit))
+			return
+		}
+		respondBindError(c, err)
+		return
+	}
+	if len(items) == 0 {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.batch_empty"))
+		return
+	}
+	if len(items) > maxBatchSize {
+		respondProblem(c, codeBatchTooLarge, tr(c, "detail.batch_too_large", len(items), maxBatchSize))
+		return
+	}
+
+	resp := BatchResponse{Mode: mode, IDs: []int{}, Results: make([]BatchResult, len(items))}
+	inputs := make([]MatchInput, len(items))
+	var invalid []FieldError
+	invalidCode := codeValidationFailed
+	for i, item := range items {
+		prefix := fmt.Sprintf("[%d].", i)
+		in, fields := item.input(c, prefix)
+		code := codeValidationFailed
+		if len(fields) == 0 {
+			var fieldErr *FieldError
+			var err error
+			in.HomeTeamID, in.AwayTeamID, fieldErr, err = a.teamIDs(c, item.matchTeams, prefix)
+			if err != nil {
+				respondStoreError(c, err)
+				return
+			}
+			if fieldErr != nil {
+				code, fields = teamProblemCode(fieldErr), []FieldError{*fieldErr}
+			}
+		}
+		inputs[i] = in
+		resp.Results[i] = BatchResult{Index: i}
+		if len(fields) > 0 {
+			resp.Results[i].Status = problemStatus[code]
+			resp.Results[i].Code = code
+			resp.Results[i].Errors = fields
+			// En modo atomic VALIDATION_FAILED tiene preferencia sobre UNKNOWN_TEAM
+			if len(invalid) == 0 || invalidCode == codeUnknownTeam {
+				invalidCode = code
+			}
+			invalid = append(invalid, fields...)
+		}
+	}
+
+	ctx := c.Request.Context()
+	if mode == batchAtomic {
+		if len(invalid) > 0 {
+			respondProblem(c, invalidCode, tr(c, "detail.batch_invalid"), invalid...)
+			return
+		}
+		created, err := a.store.CreateMatches(ctx, inputs)
+		if err != nil {
+			respondStoreError(c, err)
+			return
+		}
+		for i, m := range created {
+			resp.Results[i] = BatchResult{Index: i, Status: http.StatusCreated, ID: m.ID}
+			resp.IDs = append(resp.IDs, m.ID)
+		}
+		resp.Created = len(created)
+		c.IndentedJSON(http.StatusCreated, resp)
+		return
+	}
+
+	// best-effort: cada partido válido se crea por separado para que el
+	// fallo de uno no afecte a los demás
+	for i, in := range inputs {
+		result := &resp.Results[i]
+		if result.Status != 0 {
+			resp.Failed++
+			continue
+		}
+		m, err := a.store.CreateMatch(ctx, in)
+		if errors.Is(err, ErrUnknownTeam) {
+			result.Code, result.Status = codeUnknownTeam, problemStatus[codeUnknownTeam]
+			resp.Failed++
+			continue
+		}
+		if err != nil {
+			c.Error(err)
+			result.Code = storeFailureCode(ctx.Err(), err)
+			result.Status = problemStatus[result.Code]
+			resp.Failed++
+			continue
+		}
+		result.Status, result.ID = http.StatusCreated, m.ID
+		resp.IDs = append(resp.IDs, m.ID)
+		resp.Created++
+	}
+	status := http.StatusCreated
+	if resp.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.IndentedJSON(status, resp)
+}
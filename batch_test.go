@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCreateMatchBatch(t *testing.T) {
+	valid := func(home, away Team, date string) map[string]any {
+		return map[string]any{"homeTeamId": home.ID, "awayTeamId": away.ID, "matchDate": date}
+	}
+
+	tests := []struct {
+		name     string
+		mode     string
+		items    func(home, away Team) []map[string]any
+		status   int
+		code     string   // código del problema si la respuesta es un error
+		results  []int    // estado de cada partido en la respuesta
+		fields   []string // campos del problema en modo atomic
+		wantSize int      // partidos creados
+	}{
+		{"atomic válido", "", func(home, away Team) []map[string]any {
+			return []map[string]any{valid(home, away, "2025-05-10"), valid(away, home, "2025-05-17")}
+		}, http.StatusCreated, "", []int{201, 201}, nil, 2},
+		{"atomic con un partido inválido", "atomic", func(home, away Team) []map[string]any {
+			return []map[string]any{valid(home, away, "2025-05-10"), valid(away, home, "17/05/2025"), valid(home, home, "2025-05-24")}
+		}, http.StatusBadRequest, codeValidationFailed, nil, []string{"[1].matchDate", "[2].awayTeamId"}, 0},
+		{"atomic con un equipo desconocido", "atomic", func(home, away Team) []map[string]any {
+			return []map[string]any{valid(home, away, "2025-05-10"), {"homeTeam": "Atlantis", "awayTeamId": away.ID, "matchDate": "2025-05-17"}}
+		}, http.StatusUnprocessableEntity, codeUnknownTeam, nil, []string{"[1].homeTeam"}, 0},
+		{"best-effort válido", "best-effort", func(home, away Team) []map[string]any {
+			return []map[string]any{valid(home, away, "2025-05-10")}
+		}, http.StatusCreated, "", []int{201}, nil, 1},
+		{"best-effort mixto", "best-effort", func(home, away Team) []map[string]any {
+			return []map[string]any{valid(home, away, "2025-05-10"), valid(away, home, "17/05/2025"), {"homeTeamId": 999, "awayTeamId": away.ID, "matchDate": "2025-05-24"}, valid(away, home, "2025-05-31")}
+		}, http.StatusMultiStatus, "", []int{201, 400, 422, 201}, nil, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			home, away := s.team("Barcelona"), s.team("Real Madrid")
+			target := "/api/matches/batch"
+			if tt.mode != "" {
+				target += "?mode=" + tt.mode
+			}
+			rec := s.do(http.MethodPost, target, tt.items(home, away))
+
+			if tt.code != "" {
+				p := expectProblem(t, rec, tt.status, tt.code)
+				var fields []string
+				for _, fe := range p.Errors {
+					fields = append(fields, fe.Field)
+				}
+				if !slices.Equal(fields, tt.fields) {
+					t.Errorf("campos %v, se esperaba %v", fields, tt.fields)
+				}
+			} else {
+				expectStatus(t, rec, tt.status)
+				resp := decode[BatchResponse](t, rec)
+				var statuses []int
+				for _, r := range resp.Results {
+					statuses = append(statuses, r.Status)
+				}
+				if !slices.Equal(statuses, tt.results) || resp.Created != tt.wantSize || resp.Failed != len(tt.results)-tt.wantSize || len(resp.IDs) != tt.wantSize {
+					t.Errorf("respuesta %+v, se esperaban los estados %v", resp, tt.results)
+				}
+			}
+
+			page, err := s.store.ListMatches(t.Context(), MatchQuery{Limit: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.Total != tt.wantSize {
+				t.Errorf("se crearon %d partidos, se esperaba %d", page.Total, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestCreateMatchBatchErrors(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	item := fmt.Sprintf(`{"homeTeamId":%d,"awayTeamId":%d,"matchDate":"2025-05-10"}`, home.ID, away.ID)
+	tooMany := "[" + strings.TrimSuffix(strings.Repeat(item+",", maxBatchSize+1), ",") + "]"
+	tooLarge := `[{"homeTeam":"` + strings.Repeat("a", maxBatchBytes) + `"}]`
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		key    string
+		status int
+		code   string
+	}{
+		{"modo desconocido", "/api/matches/batch?mode=all", "[" + item + "]", "", http.StatusBadRequest, codeInvalidQuery},
+		{"lote vacío", "/api/matches/batch", "[]", "", http.StatusBadRequest, codeValidationFailed},
+		{"no es un arreglo", "/api/matches/batch", item, "", http.StatusBadRequest, codeMalformedBody},
+		{"demasiados partidos", "/api/matches/batch", tooMany, "", http.StatusRequestEntityTooLarge, codeBatchTooLarge},
+		{"cuerpo demasiado grande", "/api/matches/batch", tooLarge, "", http.StatusRequestEntityTooLarge, codeBatchTooLarge},
+		{"cuerpo demasiado grande con Idempotency-Key", "/api/matches/batch", tooLarge, "lote-1", http.StatusRequestEntityTooLarge, codeBatchTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header []string
+			if tt.key != "" {
+				header = []string{"Idempotency-Key", tt.key}
+			}
+			expectProblem(t, s.do(http.MethodPost, tt.target, tt.body, header...), tt.status, tt.code)
+		})
+	}
+}
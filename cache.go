@@ -0,0 +1,88 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cacheSettings define la cabecera Cache-Control de cada lectura. Un valor
+// vacío no envía la cabecera.
+type cacheSettings struct {
+	Live     string // partido programado o en juego
+	Finished string // partido finalizado
+	List     string // listado de partidos
+}
+
+// forMatch elige la política según el estado del partido: los finalizados
+// casi no cambian y se pueden cachear más tiempo
+func (s cacheSettings) forMatch(m Match, now time.Time) string {
+	if matchStatus(m.MatchDate, now) == statusFinished {
+		return s.Finished
+	}
+	return s.Live
+}
+
+// listETag es el ETag fuerte de una página del listado: cambia si cambia el
+// total, la página siguiente o cualquier partido de la página
+func listETag(page MatchPage, hasNext bool) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d;%t", page.Total, hasNext)
+	for _, m := range page.Matches {
+		fmt.Fprintf(h, ";%d:%s", m.ID, matchETag(m))
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// lastModified es la modificación más reciente de la página
+func lastModified(matches []Match) time.Time {
+	var latest time.Time
+	for _, m := range matches {
+		if m.UpdatedAt.After(latest) {
+			latest = m.UpdatedAt
+		}
+	}
+	return latest
+}
+
+// notModified agrega ETag, Last-Modified y Cache-Control a la respuesta y
+// evalúa el GET condicional (RFC 9110): If-None-Match tiene prioridad sobre
+// If-Modified-Since. Si el cliente ya tiene la representación actual responde
+// 304 sin cuerpo y retorna true.
+func notModified(c *gin.Context, etag string, modified time.Time, cacheControl string) bool {
+	c.Header("ETag", etag)
+	if !modified.IsZero() {
+		c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+	if cacheControl != "" {
+		c.Header("Cache-Control", cacheControl)
+	}
+
+	fresh := false
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		fresh = ifNoneMatchSatisfied(header, etag)
+	} else if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !modified.IsZero() {
+		// Last-Modified solo tiene precisión de segundos
+		fresh = !modified.Truncate(time.Second).After(since)
+	}
+	if fresh {
+		c.AbortWithStatus(http.StatusNotModified)
+	}
+	return fresh
+}
+
+// ifNoneMatchSatisfied aplica la comparación débil de If-None-Match: la lista
+// contiene etag (con o sin W/) o "*"
+func ifNoneMatchSatisfied(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/"); tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
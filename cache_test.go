@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConditionalGet(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	m := s.match(home, away, "2025-04-01")
+
+	for _, target := range []string{fmt.Sprintf("/api/matches/%d", m.ID), "/api/matches", fmt.Sprintf("/api/v2/matches/%d", m.ID)} {
+		first := s.do(http.MethodGet, target, nil)
+		expectStatus(t, first, http.StatusOK)
+		etag, modified := first.Header().Get("ETag"), first.Header().Get("Last-Modified")
+		if etag == "" || modified == "" {
+			t.Fatalf("%s: sin ETag o Last-Modified: %v", target, first.Header())
+		}
+		future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		past := m.UpdatedAt.Add(-time.Hour).UTC().Format(http.TimeFormat)
+
+		tests := []struct {
+			name   string
+			header []string
+			status int
+		}{
+			{"If-None-Match actual", []string{"If-None-Match", etag}, http.StatusNotModified},
+			{"If-None-Match débil", []string{"If-None-Match", "W/" + etag}, http.StatusNotModified},
+			{"If-None-Match en una lista", []string{"If-None-Match", `"otro", ` + etag}, http.StatusNotModified},
+			{"If-None-Match comodín", []string{"If-None-Match", "*"}, http.StatusNotModified},
+			{"If-None-Match distinto", []string{"If-None-Match", `"otro"`}, http.StatusOK},
+			{"If-Modified-Since igual", []string{"If-Modified-Since", modified}, http.StatusNotModified},
+			{"If-Modified-Since anterior", []string{"If-Modified-Since", past}, http.StatusOK},
+			{"If-None-Match tiene prioridad", []string{"If-None-Match", `"otro"`, "If-Modified-Since", future}, http.StatusOK},
+		}
+		for _, tt := range tests {
+			t.Run(target+"/"+tt.name, func(t *testing.T) {
+				rec := s.do(http.MethodGet, target, nil, tt.header...)
+				expectStatus(t, rec, tt.status)
+				if rec.Header().Get("ETag") != etag {
+					t.Errorf("ETag %q, se esperaba %q", rec.Header().Get("ETag"), etag)
+				}
+				if tt.status == http.StatusNotModified && rec.Body.Len() != 0 {
+					t.Errorf("un 304 no debe tener cuerpo: %q", rec.Body.String())
+				}
+			})
+		}
+	}
+}
+
+func TestConditionalGetAfterWrite(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	m := s.match(home, away, "2025-04-01")
+
+	for i, target := range []string{fmt.Sprintf("/api/matches/%d", m.ID), "/api/matches"} {
+		etag := s.do(http.MethodGet, target, nil).Header().Get("ETag")
+		patch := fmt.Sprintf(`{"matchDate":"2025-05-%02d"}`, i+1)
+		rec := s.do(http.MethodPatch, fmt.Sprintf("/api/matches/%d", m.ID), patch, "Content-Type", mergePatchContentType)
+		expectStatus(t, rec, http.StatusOK)
+
+		rec = s.do(http.MethodGet, target, nil, "If-None-Match", etag)
+		expectStatus(t, rec, http.StatusOK)
+		if rec.Header().Get("ETag") == etag {
+			t.Errorf("%s: el ETag no cambió tras la escritura", target)
+		}
+	}
+}
+
+func TestCacheControl(t *testing.T) {
+	s := newTestServer(t, func(cfg *Config) {
+		cfg.Cache = cacheSettings{Live: "public, max-age=5", Finished: "public, max-age=3600", List: "no-cache"}
+	})
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	finished := s.match(home, away, "2025-04-01")
+	upcoming := s.match(home, away, time.Now().AddDate(0, 0, 7).Format(time.DateOnly))
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{fmt.Sprintf("/api/matches/%d", finished.ID), "public, max-age=3600"},
+		{fmt.Sprintf("/api/matches/%d", upcoming.ID), "public, max-age=5"},
+		{"/api/matches", "no-cache"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := s.do(http.MethodGet, tt.target, nil)
+			expectStatus(t, rec, http.StatusOK)
+			if got := rec.Header().Get("Cache-Control"); got != tt.want {
+				t.Errorf("Cache-Control %q, se esperaba %q", got, tt.want)
+			}
+		})
+	}
+}
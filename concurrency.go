@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// concurrencySettings define el control de concurrencia optimista de las
+// escrituras sobre /api/matches/{id}
+type concurrencySettings struct {
+	// RequireIfMatch rechaza con 428 los PUT, PATCH y DELETE sin If-Match
+	RequireIfMatch bool
+}
+
+// matchETag es el ETag fuerte de un partido, derivado de su versión y de
+// updatedAt para que no se repita si se reinicia el almacenamiento en memoria
+func matchETag(m Match) string {
+	return `"` + strconv.Itoa(m.Version) + "-" + strconv.FormatInt(m.UpdatedAt.UnixMicro(), 36) + `"`
+}
+
+// setMatchETag agrega a la respuesta el ETag de m
+func setMatchETag(c *gin.Context, m Match) {
+	c.Header("ETag", matchETag(m))
+}
+
+// ifMatchSatisfied aplica la comparación fuerte de If-Match (RFC 9110): la
+// lista debe contener etag o "*"; un ETag débil (W/"...") nunca coincide
+func ifMatchSatisfied(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// requireIfMatch responde PRECONDITION_REQUIRED si la configuración exige
+// If-Match y la petición no lo incluye
+func (a *app) requireIfMatch(c *gin.Context) bool {
+	if a.concurrency.RequireIfMatch && c.GetHeader("If-Match") == "" {
+		respondProblem(c, codePreconditionRequired, tr(c, "detail.if_match_required"))
+		return false
+	}
+	return true
+}
+
+// checkIfMatch responde PRECONDITION_FAILED, con el ETag actual, si If-Match
+// no corresponde a la versión actual de m
+func checkIfMatch(c *gin.Context, m Match) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" || ifMatchSatisfied(header, matchETag(m)) {
+		return true
+	}
+	setMatchETag(c, m)
+	respondProblem(c, codePreconditionFailed, tr(c, "detail.precondition_failed", matchETag(m)))
+	return false
+}
+
+// expectedVersion resuelve If-Match para PUT y DELETE: retorna la versión que
+// la escritura debe encontrar o 0 si la petición no la condiciona. La versión
+// se verifica de nuevo al escribir, por lo que un cambio intermedio también
+// resulta en 412.
+func (a *app) expectedVersion(c *gin.Context, id int) (int, bool) {
+	if !a.requireIfMatch(c) {
+		return 0, false
+	}
+	if c.GetHeader("If-Match") == "" {
+		return 0, true
+	}
+	current, err := a.store.GetMatch(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err)
+		return 0, false
+	}
+	if !checkIfMatch(c, current) {
+		return 0, false
+	}
+	return current.Version, true
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// conditionalWrite es una escritura sobre un partido que admite If-Match
+type conditionalWrite struct {
+	name   string
+	method string
+	path   string // con %d para el id del partido
+	body   func(home, away Team) (contentType string, body any)
+	status int
+}
+
+var conditionalWrites = []conditionalWrite{
+	{"PUT v1", http.MethodPut, "/api/matches/%d", func(home, away Team) (string, any) {
+		return "application/json", map[string]any{"homeTeamId": home.ID, "awayTeamId": away.ID, "matchDate": "2025-05-01"}
+	}, http.StatusOK},
+	{"PATCH v1", http.MethodPatch, "/api/matches/%d", func(Team, Team) (string, any) {
+		return mergePatchContentType, `{"matchDate":"2025-05-01"}`
+	}, http.StatusOK},
+	{"DELETE v1", http.MethodDelete, "/api/matches/%d", nil, http.StatusOK},
+	{"PUT v2", http.MethodPut, "/api/v2/matches/%d", func(home, away Team) (string, any) {
+		return "application/json", map[string]any{"homeTeamId": home.ID, "awayTeamId": away.ID, "date": "2025-05-01"}
+	}, http.StatusOK},
+	{"DELETE v2", http.MethodDelete, "/api/v2/matches/%d", nil, http.StatusNoContent},
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		requireIfMatch bool
+		ifMatch        func(etag string) string
+		status         int // 0 para el estado de éxito de la escritura
+		code           string
+	}{
+		{"sin If-Match", false, nil, 0, ""},
+		{"If-Match obligatorio", true, nil, http.StatusPreconditionRequired, codePreconditionRequired},
+		{"ETag actual", true, func(etag string) string { return etag }, 0, ""},
+		{"ETag en una lista", true, func(etag string) string { return `"0-0", ` + etag }, 0, ""},
+		{"comodín", true, func(string) string { return "*" }, 0, ""},
+		{"ETag anterior", true, func(string) string { return `"0-0"` }, http.StatusPreconditionFailed, codePreconditionFailed},
+		{"ETag débil", false, func(etag string) string { return "W/" + etag }, http.StatusPreconditionFailed, codePreconditionFailed},
+	}
+	for _, w := range conditionalWrites {
+		for _, tt := range tests {
+			t.Run(w.name+"/"+tt.name, func(t *testing.T) {
+				s := newTestServer(t, func(cfg *Config) { cfg.Concurrency.RequireIfMatch = tt.requireIfMatch })
+				home, away := s.team("Barcelona"), s.team("Real Madrid")
+				m := s.match(home, away, "2025-04-01")
+
+				var header []string
+				if tt.ifMatch != nil {
+					header = append(header, "If-Match", tt.ifMatch(matchETag(m)))
+				}
+				var body any
+				if w.body != nil {
+					var contentType string
+					contentType, body = w.body(home, away)
+					header = append(header, "Content-Type", contentType)
+				}
+				rec := s.do(w.method, fmt.Sprintf(w.path, m.ID), body, header...)
+
+				if tt.status == 0 {
+					expectStatus(t, rec, w.status)
+					return
+				}
+				expectProblem(t, rec, tt.status, tt.code)
+				if tt.status == http.StatusPreconditionFailed && rec.Header().Get("ETag") != matchETag(m) {
+					t.Errorf("ETag %q, se esperaba el actual %q", rec.Header().Get("ETag"), matchETag(m))
+				}
+				if got, err := s.store.GetMatch(t.Context(), m.ID); err != nil || got.Version != m.Version {
+					t.Errorf("la escritura rechazada cambió el partido: %+v, %v", got, err)
+				}
+			})
+		}
+	}
+}
+
+func TestETagChangesWithEachWrite(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	m := s.match(home, away, "2025-04-01")
+	target := fmt.Sprintf("/api/matches/%d", m.ID)
+
+	first := s.do(http.MethodGet, target, nil).Header().Get("ETag")
+	rec := s.do(http.MethodPatch, target, `{"matchDate":"2025-05-01"}`, "Content-Type", mergePatchContentType, "If-Match", first)
+	expectStatus(t, rec, http.StatusOK)
+	second := rec.Header().Get("ETag")
+	if second == "" || second == first {
+		t.Fatalf("el ETag no cambió con la escritura: %q", second)
+	}
+	// Una segunda escritura con el ETag viejo pierde contra la primera
+	rec = s.do(http.MethodPatch, target, `{"matchDate":"2025-06-01"}`, "Content-Type", mergePatchContentType, "If-Match", first)
+	expectProblem(t, rec, http.StatusPreconditionFailed, codePreconditionFailed)
+	if got := s.do(http.MethodGet, target, nil).Header().Get("ETag"); got != second {
+		t.Errorf("ETag %q tras el 412, se esperaba %q", got, second)
+	}
+}
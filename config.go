@@ -0,0 +1,599 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Config es la configuración completa del servicio. Se carga con
+// loadConfig con la precedencia: valores por defecto < archivo de
+// configuración < variables de entorno < flags.
+type Config struct {
+	Store       string
+	AutoMigrate bool
+	Database    DatabaseConfig
+	Server      serverSettings
+	Timeouts    timeoutSettings
+	CORS        corsSettings
+	Log         logSettings
+	Metrics     metricsSettings
+	Pagination  paginationSettings
+	Concurrency concurrencySettings
+	Cache       cacheSettings
+	Idempotency idempotencySettings
+	Versions    versionSettings
+}
+
+// DatabaseConfig define cómo conectarse a PostgreSQL
+type DatabaseConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password secret
+	Name     string
+	SSLMode  string
+	Pool     poolSettings
+}
+
+// secret oculta su valor al imprimirse con fmt o serializarse
+type secret string
+
+func (s secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "******"
+}
+
+func (s secret) GoString() string { return strconv.Quote(s.String()) }
+
+func (s secret) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// ConnString arma la URL de conexión escapando usuario, contraseña y base de datos
+func (d DatabaseConfig) ConnString() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, string(d.Password)),
+		Host:     net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		Path:     "/" + d.Name,
+		RawQuery: url.Values{"sslmode": {d.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+// setting describe una opción de configuración y sus tres fuentes
+type setting struct {
+	key    string // clave en el archivo, ej. "database.pool.max_conns"
+	env    string
+	flag   string
+	def    string
+	usage  string
+	isBool bool
+	// mapSep separa las entradas "clave=valor" de las opciones que en el
+	// archivo se escriben como mapa; vacío si la opción no es un mapa
+	mapSep string
+}
+
+var settings = []setting{
+	{key: "log.level", env: "LOG_LEVEL", flag: "log-level", def: "info", usage: "nivel de log: debug, info, warn o error"},
+	{key: "log.format", env: "LOG_FORMAT", flag: "log-format", def: "json", usage: "formato de log: json o text"},
+
+	{key: "store", env: "STORE", flag: "store", def: "postgres", usage: "almacenamiento de partidos: postgres o memory"},
+	{key: "auto_migrate", env: "DB_AUTO_MIGRATE", flag: "migrate", def: "false", usage: "aplicar las migraciones pendientes al iniciar", isBool: true},
+
+	{key: "database.host", env: "DB_HOST", flag: "db-host", def: "localhost", usage: "host de PostgreSQL"},
+	{key: "database.port", env: "DB_PORT", flag: "db-port", def: "5432", usage: "puerto de PostgreSQL"},
+	{key: "database.user", env: "DB_USER", flag: "db-user", usage: "usuario de PostgreSQL"},
+	{key: "database.password", env: "DB_PASSWORD", flag: "db-password", usage: "contraseña de PostgreSQL"},
+	{key: "database.password_file", env: "DB_PASSWORD_FILE", flag: "db-password-file", usage: "archivo con la contraseña de PostgreSQL (Docker secrets)"},
+	{key: "database.name", env: "DB_NAME", flag: "db-name", usage: "base de datos de PostgreSQL"},
+	{key: "database.sslmode", env: "DB_SSLMODE", flag: "db-sslmode", def: "prefer", usage: "sslmode de la conexión: disable, allow, prefer, require, verify-ca o verify-full"},
+	{key: "database.pool.min_conns", env: "DB_POOL_MIN_CONNS", flag: "db-pool-min-conns", def: "2", usage: "conexiones mínimas abiertas"},
+	{key: "database.pool.max_conns", env: "DB_POOL_MAX_CONNS", flag: "db-pool-max-conns", def: "10", usage: "conexiones máximas simultáneas"},
+	{key: "database.pool.max_conn_idle_time", env: "DB_POOL_MAX_CONN_IDLE_TIME", flag: "db-pool-max-conn-idle-time", def: "5m", usage: "tiempo máximo de inactividad de una conexión"},
+	{key: "database.pool.max_conn_lifetime", env: "DB_POOL_MAX_CONN_LIFETIME", flag: "db-pool-max-conn-lifetime", def: "1h", usage: "vida máxima de una conexión"},
+	{key: "database.pool.health_check_period", env: "DB_POOL_HEALTH_CHECK_PERIOD", flag: "db-pool-health-check-period", def: "30s", usage: "frecuencia del chequeo de conexiones inactivas"},
+
+	{key: "server.addr", env: "HTTP_ADDR", flag: "http-addr", def: "0.0.0.0:8080", usage: "dirección de escucha"},
+	{key: "server.read_header_timeout", env: "HTTP_READ_HEADER_TIMEOUT", flag: "http-read-header-timeout", def: "5s", usage: "tiempo máximo para leer las cabeceras"},
+	{key: "server.read_timeout", env: "HTTP_READ_TIMEOUT", flag: "http-read-timeout", def: "10s", usage: "tiempo máximo para leer la petición"},
+	{key: "server.write_timeout", env: "HTTP_WRITE_TIMEOUT", flag: "http-write-timeout", def: "15s", usage: "tiempo máximo para escribir la respuesta"},
+	{key: "server.idle_timeout", env: "HTTP_IDLE_TIMEOUT", flag: "http-idle-timeout", def: "60s", usage: "tiempo máximo de una conexión keep-alive inactiva"},
+	{key: "server.shutdown_grace_period", env: "SHUTDOWN_GRACE_PERIOD", flag: "shutdown-grace-period", def: "15s", usage: "espera máxima a las peticiones en curso al detenerse"},
+
+	{key: "timeouts.default", env: "REQUEST_TIMEOUT", flag: "request-timeout", def: "5s", usage: "tiempo máximo de cualquier ruta"},
+	{key: "timeouts.routes", env: "REQUEST_TIMEOUT_ROUTES", flag: "request-timeout-routes", usage: "excepciones por ruta, ej. \"GET /api/matches=10s,PATCH /api/matches/:id/goals=2s\"", mapSep: ","},
+
+	{key: "cors.allowed_origins", env: "CORS_ALLOWED_ORIGINS", flag: "cors-allowed-origins", def: "*", usage: "orígenes permitidos separados por comas; admite * y subdominios como https://*.laliga.com"},
+	{key: "cors.allowed_headers", env: "CORS_ALLOWED_HEADERS", flag: "cors-allowed-headers", def: "Content-Type,Authorization,Accept-Language,If-Match,If-None-Match,If-Modified-Since,Idempotency-Key,X-Request-ID", usage: "cabeceras que el navegador puede enviar"},
+	{key: "cors.exposed_headers", env: "CORS_EXPOSED_HEADERS", flag: "cors-exposed-headers", def: "Content-Length,Content-Type,ETag,Last-Modified,Location,Link,X-Request-ID,X-Total-Count,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,Retry-After,Deprecation,Sunset,Idempotent-Replayed", usage: "cabeceras de respuesta visibles para el navegador"},
+	{key: "cors.allow_credentials", env: "CORS_ALLOW_CREDENTIALS", flag: "cors-allow-credentials", def: "false", usage: "permitir cookies y cabeceras de autenticación", isBool: true},
+	{key: "cors.max_age", env: "CORS_MAX_AGE", flag: "cors-max-age", def: "10m", usage: "tiempo que el navegador puede cachear un preflight"},
+	{key: "cors.route_methods", env: "CORS_ROUTE_METHODS", flag: "cors-route-methods", usage: "métodos permitidos por ruta, ej. \"/api/matches=GET;/api/matches/:id=GET,PUT\"; por defecto los registrados en cada ruta", mapSep: ";"},
+
+	{key: "metrics.enabled", env: "METRICS_ENABLED", flag: "metrics", def: "true", usage: "exponer las métricas de Prometheus", isBool: true},
+	{key: "metrics.path", env: "METRICS_PATH", flag: "metrics-path", def: "/metrics", usage: "ruta del endpoint de métricas"},
+
+	{key: "pagination.default_limit", env: "PAGE_SIZE_DEFAULT", flag: "page-size-default", def: "20", usage: "partidos por página cuando no se indica limit"},
+	{key: "pagination.max_limit", env: "PAGE_SIZE_MAX", flag: "page-size-max", def: "100", usage: "valor máximo de limit en los listados"},
+
+	{key: "cache.live", env: "CACHE_CONTROL_LIVE", flag: "cache-control-live", def: "public, max-age=5", usage: "Cache-Control de un partido programado o en juego"},
+	{key: "cache.finished", env: "CACHE_CONTROL_FINISHED", flag: "cache-control-finished", def: "public, max-age=3600", usage: "Cache-Control de un partido finalizado"},
+	{key: "cache.list", env: "CACHE_CONTROL_LIST", flag: "cache-control-list", def: "public, max-age=5", usage: "Cache-Control del listado de partidos"},
+
+	{key: "idempotency.retention", env: "IDEMPOTENCY_RETENTION", flag: "idempotency-retention", def: "24h", usage: "tiempo que se guarda la respuesta de cada Idempotency-Key"},
+	{key: "idempotency.purge_interval", env: "IDEMPOTENCY_PURGE_INTERVAL", flag: "idempotency-purge-interval", def: "1h", usage: "cada cuánto se eliminan las Idempotency-Key vencidas"},
+
+	{key: "api.v1_deprecation", env: "API_V1_DEPRECATION", flag: "api-v1-deprecation", def: "2026-10-18", usage: "fecha (YYYY-MM-DD) de la cabecera Deprecation en /api; vacío para no enviarla"},
+	{key: "api.v1_sunset", env: "API_V1_SUNSET", flag: "api-v1-sunset", def: "2027-06-30", usage: "fecha (YYYY-MM-DD) de la cabecera Sunset en /api; vacío para no enviarla"},
+
+	{key: "concurrency.require_if_match", env: "REQUIRE_IF_MATCH", flag: "require-if-match", def: "false", usage: "exigir If-Match en PUT, PATCH y DELETE de un partido (428 si falta)", isBool: true},
+}
+
+// settingValue es el flag.Value de una opción; recuerda si se usó
+type settingValue struct {
+	value  string
+	set    bool
+	isBool bool
+}
+
+func (v *settingValue) String() string { return v.value }
+
+func (v *settingValue) Set(s string) error {
+	v.value, v.set = s, true
+	return nil
+}
+
+func (v *settingValue) IsBoolFlag() bool { return v.isBool }
+
+// configFlags son los flags registrados por registerConfigFlags
+type configFlags struct {
+	file   *string
+	values map[string]*settingValue
+}
+
+// registerConfigFlags registra en fs un flag por cada opción y el flag -config
+func registerConfigFlags(fs *flag.FlagSet) *configFlags {
+	f := &configFlags{
+		file:   fs.String("config", "", "archivo de configuración YAML o TOML (también CONFIG_FILE)"),
+		values: map[string]*settingValue{},
+	}
+	for _, s := range settings {
+		v := &settingValue{isBool: s.isBool}
+		f.values[s.key] = v
+		usage := fmt.Sprintf("%s (env %s)", s.usage, s.env)
+		if s.def != "" {
+			usage += fmt.Sprintf(" (por defecto %q)", s.def)
+		}
+		fs.Var(v, s.flag, usage)
+	}
+	return f
+}
+
+// loadConfig combina las fuentes de configuración y valida el resultado.
+// Una variable de entorno definida pero vacía también reemplaza el valor
+// anterior. Todos los errores encontrados se reportan juntos.
+func loadConfig(flags *configFlags, lookupEnv func(string) (string, bool)) (Config, error) {
+	values := map[string]string{}
+	for _, s := range settings {
+		values[s.key] = s.def
+	}
+
+	file := *flags.file
+	if file == "" {
+		file, _ = lookupEnv("CONFIG_FILE")
+	}
+	if file != "" {
+		fromFile, err := readConfigFile(file)
+		if err != nil {
+			return Config{}, err
+		}
+		for k, v := range fromFile {
+			values[k] = v
+		}
+	}
+
+	for _, s := range settings {
+		if v, ok := lookupEnv(s.env); ok {
+			values[s.key] = v
+		}
+		if v := flags.values[s.key]; v != nil && v.set {
+			values[s.key] = v.value
+		}
+	}
+
+	return parseConfig(values)
+}
+
+// readConfigFile lee un archivo YAML o TOML y lo aplana a claves como
+// "database.pool.max_conns"
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el archivo de configuración: %w", err)
+	}
+
+	var tree map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("formato de configuración desconocido %q, use .yaml, .yml o .toml", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	known := map[string]setting{}
+	for _, s := range settings {
+		known[s.key] = s
+	}
+
+	values := map[string]string{}
+	var errs []error
+	var flatten func(prefix string, node map[string]any)
+	flatten = func(prefix string, node map[string]any) {
+		for k, v := range node {
+			key := prefix + k
+			s, isKnown := known[key]
+			if entries, ok := v.(map[string]any); ok && isKnown && s.mapSep != "" {
+				var flat []string
+				for entryKey, entryValue := range entries {
+					flat = append(flat, entryKey+"="+flatValue(entryValue))
+				}
+				slices.Sort(flat)
+				values[key] = strings.Join(flat, s.mapSep)
+				continue
+			}
+			if child, ok := v.(map[string]any); ok {
+				flatten(key+".", child)
+				continue
+			}
+			if !isKnown {
+				errs = append(errs, fmt.Errorf("%s: opción desconocida %q", path, key))
+				continue
+			}
+			values[key] = flatValue(v)
+		}
+	}
+	flatten("", tree)
+	return values, errors.Join(errs...)
+}
+
+// flatValue convierte un valor del archivo a texto; las listas se unen con
+// comas y una fecha YAML sin comillas vuelve a YYYY-MM-DD
+func flatValue(v any) string {
+	if t, ok := v.(time.Time); ok && t.Equal(t.Truncate(24*time.Hour)) {
+		return t.Format(time.DateOnly)
+	}
+	if list, ok := v.([]any); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+// configParser convierte los valores de texto acumulando los errores
+type configParser struct {
+	values map[string]string
+	errs   []error
+	failed map[string]bool
+}
+
+func (p *configParser) fail(key, format string, args ...any) {
+	p.errs = append(p.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	p.failed[key] = true
+}
+
+// ok indica si todas las claves se pudieron interpretar, para no reportar
+// errores derivados de un valor que ya falló
+func (p *configParser) ok(keys ...string) bool {
+	for _, key := range keys {
+		if p.failed[key] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *configParser) string(key string) string {
+	return strings.TrimSpace(p.values[key])
+}
+
+func (p *configParser) int(key string) int {
+	n, err := strconv.Atoi(p.string(key))
+	if err != nil {
+		p.fail(key, "debe ser un número entero, se obtuvo %q", p.values[key])
+	}
+	return n
+}
+
+// list separa por comas y descarta los elementos vacíos
+func (p *configParser) list(key string) []string {
+	var items []string
+	for _, item := range strings.Split(p.string(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (p *configParser) bool(key string) bool {
+	b, err := strconv.ParseBool(p.string(key))
+	if err != nil {
+		p.fail(key, "debe ser true o false, se obtuvo %q", p.values[key])
+	}
+	return b
+}
+
+// duration exige una duración positiva como "30s" o "5m"
+func (p *configParser) duration(key string) time.Duration {
+	d, err := time.ParseDuration(p.string(key))
+	if err != nil {
+		p.fail(key, "debe ser una duración como 30s o 5m, se obtuvo %q", p.values[key])
+	} else if d <= 0 {
+		p.fail(key, "debe ser mayor que 0")
+	}
+	return d
+}
+
+// date interpreta una fecha YYYY-MM-DD; un valor vacío es la fecha cero
+func (p *configParser) date(key string) time.Time {
+	v := p.string(key)
+	if v == "" {
+		return time.Time{}
+	}
+	d, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		p.fail(key, "debe ser una fecha YYYY-MM-DD, se obtuvo %q", p.values[key])
+	}
+	return d
+}
+
+func (p *configParser) oneOf(key string, allowed ...string) string {
+	v := p.string(key)
+	if !slices.Contains(allowed, v) {
+		p.fail(key, "debe ser uno de %s, se obtuvo %q", strings.Join(allowed, ", "), v)
+	}
+	return v
+}
+
+func parseConfig(values map[string]string) (Config, error) {
+	p := &configParser{values: values, failed: map[string]bool{}}
+	var c Config
+
+	if err := c.Log.Level.UnmarshalText([]byte(p.oneOf("log.level", "debug", "info", "warn", "error"))); err != nil {
+		p.fail("log.level", "%v", err)
+	}
+	c.Log.Format = p.oneOf("log.format", "json", "text")
+
+	c.Store = p.oneOf("store", "postgres", "memory")
+	c.AutoMigrate = p.bool("auto_migrate")
+
+	db := &c.Database
+	db.Host = p.string("database.host")
+	db.Port = p.int("database.port")
+	db.User = p.string("database.user")
+	db.Password = secret(values["database.password"])
+	db.Name = p.string("database.name")
+	db.SSLMode = p.oneOf("database.sslmode", "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	if p.ok("database.port") && (db.Port < 1 || db.Port > 65535) {
+		p.fail("database.port", "debe estar entre 1 y 65535")
+	}
+	if file := p.string("database.password_file"); file != "" {
+		if db.Password != "" {
+			p.fail("database.password_file", "no puede usarse junto con database.password")
+		} else if data, err := os.ReadFile(file); err != nil {
+			p.fail("database.password_file", "no se pudo leer: %v", err)
+		} else {
+			db.Password = secret(strings.TrimRight(string(data), "\r\n"))
+		}
+	}
+	if c.Store == "postgres" {
+		for _, key := range []string{"database.host", "database.user", "database.name"} {
+			if p.string(key) == "" {
+				p.fail(key, "es obligatorio con store=postgres")
+			}
+		}
+	}
+
+	pool := &db.Pool
+	pool.MinConns = int32(p.int("database.pool.min_conns"))
+	pool.MaxConns = int32(p.int("database.pool.max_conns"))
+	pool.MaxConnIdleTime = p.duration("database.pool.max_conn_idle_time")
+	pool.MaxConnLifetime = p.duration("database.pool.max_conn_lifetime")
+	pool.HealthCheckPeriod = p.duration("database.pool.health_check_period")
+	if p.ok("database.pool.max_conns") && pool.MaxConns < 1 {
+		p.fail("database.pool.max_conns", "debe ser mayor que 0")
+	}
+	if p.ok("database.pool.min_conns", "database.pool.max_conns") && (pool.MinConns < 0 || pool.MinConns > pool.MaxConns) {
+		p.fail("database.pool.min_conns", "debe estar entre 0 y database.pool.max_conns (%d)", pool.MaxConns)
+	}
+
+	srv := &c.Server
+	srv.Addr = p.string("server.addr")
+	if _, _, err := net.SplitHostPort(srv.Addr); err != nil {
+		p.fail("server.addr", "debe tener la forma host:puerto, se obtuvo %q", srv.Addr)
+	}
+	srv.ReadHeaderTimeout = p.duration("server.read_header_timeout")
+	srv.ReadTimeout = p.duration("server.read_timeout")
+	srv.WriteTimeout = p.duration("server.write_timeout")
+	srv.IdleTimeout = p.duration("server.idle_timeout")
+	srv.ShutdownGrace = p.duration("server.shutdown_grace_period")
+
+	c.Timeouts.Default = p.duration("timeouts.default")
+	routes, err := parseRouteTimeouts(p.string("timeouts.routes"))
+	if err != nil {
+		p.fail("timeouts.routes", "%v", err)
+	}
+	c.Timeouts.PerRoute = routes
+	for route, d := range routes {
+		if p.ok("server.write_timeout") && d >= srv.WriteTimeout {
+			p.fail("timeouts.routes", "el tiempo de %q (%s) debe ser menor que server.write_timeout (%s)", route, d, srv.WriteTimeout)
+		}
+	}
+	if p.ok("timeouts.default", "server.write_timeout") && c.Timeouts.Default >= srv.WriteTimeout {
+		p.fail("timeouts.default", "debe ser menor que server.write_timeout (%s) para poder responder 504", srv.WriteTimeout)
+	}
+
+	c.CORS.AllowedOrigins = p.list("cors.allowed_origins")
+	c.CORS.AllowedHeaders = p.list("cors.allowed_headers")
+	c.CORS.ExposedHeaders = p.list("cors.exposed_headers")
+	c.CORS.AllowCredentials = p.bool("cors.allow_credentials")
+	c.CORS.MaxAge = p.duration("cors.max_age")
+	if err := validateOrigins(c.CORS.AllowedOrigins); err != nil {
+		p.fail("cors.allowed_origins", "%v", err)
+	}
+	if c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowedOrigins, "*") {
+		p.fail("cors.allow_credentials", "no puede usarse con el origen \"*\"; indique los orígenes permitidos")
+	}
+	methods, err := parseRouteMethods(p.string("cors.route_methods"))
+	if err != nil {
+		p.fail("cors.route_methods", "%v", err)
+	}
+	c.CORS.RouteMethods = methods
+
+	c.Metrics.Enabled = p.bool("metrics.enabled")
+	c.Metrics.Path = p.string("metrics.path")
+	if !strings.HasPrefix(c.Metrics.Path, "/") {
+		p.fail("metrics.path", "debe comenzar con \"/\"")
+	}
+
+	c.Pagination.DefaultLimit = p.int("pagination.default_limit")
+	c.Pagination.MaxLimit = p.int("pagination.max_limit")
+	if p.ok("pagination.max_limit") && c.Pagination.MaxLimit < 1 {
+		p.fail("pagination.max_limit", "debe ser mayor que 0")
+	}
+	if p.ok("pagination.default_limit", "pagination.max_limit") && (c.Pagination.DefaultLimit < 1 || c.Pagination.DefaultLimit > c.Pagination.MaxLimit) {
+		p.fail("pagination.default_limit", "debe estar entre 1 y pagination.max_limit (%d)", c.Pagination.MaxLimit)
+	}
+
+	c.Concurrency.RequireIfMatch = p.bool("concurrency.require_if_match")
+
+	c.Idempotency.Retention = p.duration("idempotency.retention")
+	if p.ok("idempotency.retention") && c.Idempotency.Retention <= 0 {
+		p.fail("idempotency.retention", "debe ser mayor que 0")
+	}
+	c.Idempotency.PurgeInterval = p.duration("idempotency.purge_interval")
+	if p.ok("idempotency.purge_interval") && c.Idempotency.PurgeInterval <= 0 {
+		p.fail("idempotency.purge_interval", "debe ser mayor que 0")
+	}
+
+	c.Cache.Live = p.string("cache.live")
+	c.Cache.Finished = p.string("cache.finished")
+	c.Cache.List = p.string("cache.list")
+
+	c.Versions.V1Deprecation = p.date("api.v1_deprecation")
+	c.Versions.V1Sunset = p.date("api.v1_sunset")
+	if p.ok("api.v1_deprecation", "api.v1_sunset") && !c.Versions.V1Sunset.IsZero() && c.Versions.V1Sunset.Before(c.Versions.V1Deprecation) {
+		p.fail("api.v1_sunset", "no puede ser anterior a api.v1_deprecation")
+	}
+
+	return c, errors.Join(p.errs...)
+}
+
+// formatConfigDate es la inversa de configParser.date
+func formatConfigDate(d time.Time) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(time.DateOnly)
+}
+
+// printConfig escribe la configuración efectiva en formato YAML ocultando secretos
+func printConfig(w io.Writer, c Config) error {
+	routes := map[string]string{}
+	for route, d := range c.Timeouts.PerRoute {
+		routes[route] = d.String()
+	}
+	out := map[string]any{
+		"log": map[string]any{
+			"level":  strings.ToLower(c.Log.Level.String()),
+			"format": c.Log.Format,
+		},
+		"store":        c.Store,
+		"auto_migrate": c.AutoMigrate,
+		"database": map[string]any{
+			"host":     c.Database.Host,
+			"port":     c.Database.Port,
+			"user":     c.Database.User,
+			"password": c.Database.Password.String(),
+			"name":     c.Database.Name,
+			"sslmode":  c.Database.SSLMode,
+			"pool": map[string]any{
+				"min_conns":           c.Database.Pool.MinConns,
+				"max_conns":           c.Database.Pool.MaxConns,
+				"max_conn_idle_time":  c.Database.Pool.MaxConnIdleTime.String(),
+				"max_conn_lifetime":   c.Database.Pool.MaxConnLifetime.String(),
+				"health_check_period": c.Database.Pool.HealthCheckPeriod.String(),
+			},
+		},
+		"server": map[string]any{
+			"addr":                  c.Server.Addr,
+			"read_header_timeout":   c.Server.ReadHeaderTimeout.String(),
+			"read_timeout":          c.Server.ReadTimeout.String(),
+			"write_timeout":         c.Server.WriteTimeout.String(),
+			"idle_timeout":          c.Server.IdleTimeout.String(),
+			"shutdown_grace_period": c.Server.ShutdownGrace.String(),
+		},
+		"timeouts": map[string]any{
+			"default": c.Timeouts.Default.String(),
+			"routes":  routes,
+		},
+		"cors": map[string]any{
+			"allowed_origins":   c.CORS.AllowedOrigins,
+			"allowed_headers":   c.CORS.AllowedHeaders,
+			"exposed_headers":   c.CORS.ExposedHeaders,
+			"allow_credentials": c.CORS.AllowCredentials,
+			"max_age":           c.CORS.MaxAge.String(),
+			"route_methods":     c.CORS.RouteMethods,
+		},
+		"metrics": map[string]any{
+			"enabled": c.Metrics.Enabled,
+			"path":    c.Metrics.Path,
+		},
+		"pagination": map[string]any{
+			"default_limit": c.Pagination.DefaultLimit,
+			"max_limit":     c.Pagination.MaxLimit,
+		},
+		"cache": map[string]any{
+			"live":     c.Cache.Live,
+			"finished": c.Cache.Finished,
+			"list":     c.Cache.List,
+		},
+		"idempotency": map[string]any{
+			"retention":      c.Idempotency.Retention.String(),
+			"purge_interval": c.Idempotency.PurgeInterval.String(),
+		},
+		"concurrency": map[string]any{
+			"require_if_match": c.Concurrency.RequireIfMatch,
+		},
+		"api": map[string]any{
+			"v1_deprecation": formatConfigDate(c.Versions.V1Deprecation),
+			"v1_sunset":      formatConfigDate(c.Versions.V1Sunset),
+		},
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	defer enc.Close()
+	return enc.Encode(out)
+}
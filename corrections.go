@@ -0,0 +1,229 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// voidRequest es el cuerpo para anular un evento
+type voidRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// goalReversalRequest es el cuerpo para descontar un gol; sin side se
+// descuenta un gol sin equipo
+type goalReversalRequest struct {
+	Side   string `json:"side" binding:"omitempty,oneof=home away" enums:"home,away"`
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// cardReversalRequest es el cuerpo para descontar una tarjeta; sin team se
+// anula la última tarjeta de cualquier equipo
+type cardReversalRequest struct {
+	Team   string `json:"team" binding:"omitempty,oneof=home away" enums:"home,away"`
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// CounterChange es el cambio de un contador del partido
+type CounterChange struct {
+	Field string `json:"field" example:"homeScore"`
+	From  int    `json:"from" example:"2"`
+	To    int    `json:"to" example:"1"`
+}
+
+// MatchCorrection es la respuesta de una anulación: el evento anulado y los
+// contadores que cambiaron, para que los clientes puedan mostrar la corrección
+type MatchCorrection struct {
+	Message string          `json:"message"`
+	Event   MatchEvent      `json:"event"`
+	Changes []CounterChange `json:"changes"`
+	Match   Match           `json:"match"`
+}
+
+// counterCorrection son los eventos de Type y Team que registra o anula una
+// corrección de contadores: Count positivo agrega eventos sin minuto y
+// negativo anula los últimos vigentes
+type counterCorrection struct {
+	Type  string
+	Team  string
+	Count int
+}
+
+// counterCorrections retorna los eventos que llevan los contadores de from a
+// los de to. Los goles sin equipo son los de goals que no suman en ningún
+// marcador; las tarjetas se anulan sin importar el equipo.
+func counterCorrections(from, to Match) []counterCorrection {
+	all := []counterCorrection{
+		{eventGoal, sideHome, to.HomeScore - from.HomeScore},
+		{eventGoal, sideAway, to.AwayScore - from.AwayScore},
+		{eventGoal, "", to.unattributedGoals() - from.unattributedGoals()},
+		{eventYellowCard, "", to.YellowCards - from.YellowCards},
+		{eventRedCard, "", to.RedCards - from.RedCards},
+		{eventExtraTime, "", to.ExtraTime - from.ExtraTime},
+	}
+	var changes []counterCorrection
+	for _, c := range all {
+		if c.Count != 0 {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// voidEvent anula el evento indicado por v y responde la corrección. El
+// motivo se guarda sin espacios al principio ni al final y no puede quedar
+// vacío.
+func (a *app) voidEvent(c *gin.Context, v MatchEventVoid) {
+	if v.Reason = strings.TrimSpace(v.Reason); v.Reason == "" {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"),
+			FieldError{Field: "reason", Code: fieldRequired, Message: tr(c, "validation.required")})
+		return
+	}
+	event, match, err := a.store.VoidMatchEvent(c.Request.Context(), v)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	correction := MatchCorrection{Message: tr(c, "match.event_voided"), Event: event, Changes: []CounterChange{}, Match: match}
+	for _, counter := range eventCounters(&match, event.Type, event.Team) {
+		correction.Changes = append(correction.Changes, CounterChange{Field: counter.name, From: *counter.value + 1, To: *counter.value})
+	}
+	c.IndentedJSON(http.StatusOK, correction)
+}
+
+// voidMatchEvent godoc
+// @Summary Anular un evento
+// @Description Anula un evento de la cronología, por ejemplo un gol anulado por el VAR, y descuenta su contador.
+// @Description El evento sigue en la cronología con voidedAt y voidReason. No se puede anular dos veces
+// @Description ni dejar un contador en negativo.
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Param eventId path int true "ID del evento"
+// @Param void body voidRequest true "Motivo de la anulación"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 200 {object} MatchCorrection
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id}/events/{eventId}/void [post]
+func (a *app) voidMatchEvent(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parsePathID(c, "eventId")
+	if !ok {
+		return
+	}
+	var req voidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	a.voidEvent(c, MatchEventVoid{MatchID: matchID, EventID: eventID, Reason: req.Reason})
+}
+
+// reverseGoal godoc
+// @Summary Descontar un gol
+// @Description Anula el último gol vigente del equipo indicado. Si el gol es anterior a la cronología se descuenta
+// @Description el marcador y se agrega a la cronología como anulado. Sin side se descuenta un gol sin equipo,
+// @Description de los registrados antes de separar los marcadores, solo de goals.
+// @Description No se puede dejar el marcador ni los goles sin equipo en negativo.
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Param reversal body goalReversalRequest true "Equipo y motivo"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 200 {object} MatchCorrection
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id}/goals/reversal [post]
+func (a *app) reverseGoal(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+	var req goalReversalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	a.voidEvent(c, MatchEventVoid{MatchID: matchID, Type: eventGoal, Team: req.Side, Reason: req.Reason})
+}
+
+// reverseYellowCard godoc
+// @Summary Descontar una tarjeta amarilla
+// @Description Anula la última tarjeta amarilla vigente, del equipo indicado si se envía team.
+// @Description No se puede dejar el contador en negativo.
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Param reversal body cardReversalRequest true "Equipo y motivo"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 200 {object} MatchCorrection
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id}/yellowcards/reversal [post]
+func (a *app) reverseYellowCard(c *gin.Context) {
+	a.reverseCard(c, eventYellowCard)
+}
+
+// reverseRedCard godoc
+// @Summary Descontar una tarjeta roja
+// @Description Anula la última tarjeta roja vigente, del equipo indicado si se envía team.
+// @Description No se puede dejar el contador en negativo.
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Param reversal body cardReversalRequest true "Equipo y motivo"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 200 {object} MatchCorrection
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id}/redcards/reversal [post]
+func (a *app) reverseRedCard(c *gin.Context) {
+	a.reverseCard(c, eventRedCard)
+}
+
+func (a *app) reverseCard(c *gin.Context, eventType string) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+	var req cardReversalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	a.voidEvent(c, MatchEventVoid{MatchID: matchID, Type: eventType, Team: req.Team, Reason: req.Reason})
+}
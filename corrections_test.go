@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestReverseCounters(t *testing.T) {
+	goal := func(side string) MatchEventInput { return MatchEventInput{Type: eventGoal, Team: side} }
+	card := func(eventType, side string) MatchEventInput { return MatchEventInput{Type: eventType, Team: side} }
+
+	tests := []struct {
+		name    string
+		route   string
+		body    map[string]string
+		legacy  func(m *Match) // contadores sin eventos, anteriores a la cronología
+		events  []MatchEventInput
+		voided  int // índice en events del evento anulado; -1 si se agrega uno nuevo
+		changes []CounterChange
+	}{
+		{"gol del local", "goals", map[string]string{"side": sideHome}, nil,
+			[]MatchEventInput{goal(sideHome), goal(sideAway), goal(sideHome)}, 2,
+			[]CounterChange{{"goals", 3, 2}, {"homeScore", 2, 1}}},
+		{"gol del visitante", "goals", map[string]string{"side": sideAway}, nil,
+			[]MatchEventInput{goal(sideAway), goal(sideHome)}, 0,
+			[]CounterChange{{"goals", 2, 1}, {"awayScore", 1, 0}}},
+		{"gol sin equipo", "goals", map[string]string{}, func(m *Match) { m.Goals = 1 },
+			[]MatchEventInput{goal(sideHome)}, -1,
+			[]CounterChange{{"goals", 2, 1}}},
+		{"gol anterior a la cronología", "goals", map[string]string{"side": sideAway}, func(m *Match) { m.Goals, m.AwayScore = 1, 1 },
+			nil, -1,
+			[]CounterChange{{"goals", 1, 0}, {"awayScore", 1, 0}}},
+		{"amarilla del visitante", "yellowcards", map[string]string{"team": sideAway}, nil,
+			[]MatchEventInput{card(eventYellowCard, sideHome), card(eventYellowCard, sideAway), card(eventYellowCard, sideHome)}, 1,
+			[]CounterChange{{"yellowCards", 3, 2}}},
+		{"última amarilla", "yellowcards", map[string]string{}, nil,
+			[]MatchEventInput{card(eventYellowCard, sideHome), card(eventYellowCard, sideAway)}, 1,
+			[]CounterChange{{"yellowCards", 2, 1}}},
+		{"roja anterior a la cronología", "redcards", map[string]string{}, func(m *Match) { m.RedCards = 1 },
+			nil, -1,
+			[]CounterChange{{"redCards", 1, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			m := s.match(s.team("Barcelona"), s.team("Real Madrid"), "2025-04-01")
+			if tt.legacy != nil {
+				tt.legacy(&m)
+				if _, err := s.store.ReplaceMatch(t.Context(), m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var events []MatchEvent
+			for _, in := range tt.events {
+				in.MatchID = m.ID
+				e, _, err := s.store.AddMatchEvent(t.Context(), in)
+				if err != nil {
+					t.Fatal(err)
+				}
+				events = append(events, e)
+			}
+
+			body := map[string]string{"reason": "  mal registrado\n"}
+			for k, v := range tt.body {
+				body[k] = v
+			}
+			rec := s.do(http.MethodPost, fmt.Sprintf("/api/matches/%d/%s/reversal", m.ID, tt.route), body)
+			expectStatus(t, rec, http.StatusOK)
+			got := decode[MatchCorrection](t, rec)
+			if !slices.Equal(got.Changes, tt.changes) {
+				t.Errorf("cambios %+v, se esperaba %+v", got.Changes, tt.changes)
+			}
+			if got.Event.VoidedAt == nil || got.Event.VoidReason != "mal registrado" {
+				t.Errorf("el evento %+v no quedó anulado con el motivo", got.Event)
+			}
+			if tt.voided >= 0 && got.Event.ID != events[tt.voided].ID {
+				t.Errorf("se anuló el evento %d, se esperaba %d", got.Event.ID, events[tt.voided].ID)
+			}
+			if tt.voided < 0 && slices.ContainsFunc(events, func(e MatchEvent) bool { return e.ID == got.Event.ID }) {
+				t.Errorf("se anuló el evento %d, se esperaba uno nuevo", got.Event.ID)
+			}
+
+			stored, err := s.store.GetMatch(t.Context(), m.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Goals != got.Match.Goals || stored.HomeScore != got.Match.HomeScore || stored.AwayScore != got.Match.AwayScore ||
+				stored.YellowCards != got.Match.YellowCards || stored.RedCards != got.Match.RedCards {
+				t.Errorf("el partido guardado %+v no coincide con la respuesta %+v", stored, got.Match)
+			}
+		})
+	}
+}
+
+func TestReverseCountersErrors(t *testing.T) {
+	s := newTestServer(t)
+	m := s.match(s.team("Barcelona"), s.team("Real Madrid"), "2025-04-01")
+	if _, _, err := s.store.AddMatchEvent(t.Context(), MatchEventInput{MatchID: m.ID, Type: eventGoal, Team: sideHome}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		route  string
+		body   map[string]string
+		status int
+		code   string
+	}{
+		{"sin motivo", "goals", map[string]string{"side": sideHome}, http.StatusBadRequest, codeValidationFailed},
+		{"motivo en blanco", "goals", map[string]string{"side": sideHome, "reason": "   "}, http.StatusBadRequest, codeValidationFailed},
+		{"motivo en blanco de una tarjeta", "yellowcards", map[string]string{"reason": "\t"}, http.StatusBadRequest, codeValidationFailed},
+		{"equipo inválido", "goals", map[string]string{"side": "both", "reason": "x"}, http.StatusBadRequest, codeValidationFailed},
+		{"sin goles del visitante", "goals", map[string]string{"side": sideAway, "reason": "x"}, http.StatusConflict, codeCounterBelowZero},
+		{"sin goles sin equipo", "goals", map[string]string{"reason": "x"}, http.StatusConflict, codeCounterBelowZero},
+		{"sin tarjetas", "redcards", map[string]string{"reason": "x"}, http.StatusConflict, codeCounterBelowZero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(http.MethodPost, fmt.Sprintf("/api/matches/%d/%s/reversal", m.ID, tt.route), tt.body)
+			expectProblem(t, rec, tt.status, tt.code)
+		})
+	}
+	expectProblem(t, s.do(http.MethodPost, "/api/matches/999/goals/reversal", map[string]string{"side": sideHome, "reason": "x"}),
+		http.StatusNotFound, codeMatchNotFound)
+
+	got, err := s.store.GetMatch(t.Context(), m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Goals != 1 || got.HomeScore != 1 || got.Version != m.Version+1 {
+		t.Errorf("una anulación rechazada cambió el partido: %+v", got)
+	}
+}
+
+func TestVoidMatchEvent(t *testing.T) {
+	s := newTestServer(t)
+	m := s.match(s.team("Barcelona"), s.team("Real Madrid"), "2025-04-01")
+	var events []MatchEvent
+	for _, in := range []MatchEventInput{
+		{Type: eventGoal, Team: sideHome},
+		{Type: eventGoal, Team: sideAway},
+		{Type: eventSubstitution, Team: sideAway},
+	} {
+		in.MatchID = m.ID
+		e, _, err := s.store.AddMatchEvent(t.Context(), in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+	void := func(eventID int) string { return fmt.Sprintf("/api/matches/%d/events/%d/void", m.ID, eventID) }
+	reason := map[string]string{"reason": "VAR: fuera de juego"}
+
+	rec := s.do(http.MethodPost, void(events[0].ID), reason)
+	expectStatus(t, rec, http.StatusOK)
+	got := decode[MatchCorrection](t, rec)
+	if want := []CounterChange{{"goals", 2, 1}, {"homeScore", 1, 0}}; !slices.Equal(got.Changes, want) {
+		t.Errorf("cambios %+v, se esperaba %+v", got.Changes, want)
+	}
+	if got.Match.Result != resultAway {
+		t.Errorf("result %q tras anular el gol local, se esperaba %q", got.Match.Result, resultAway)
+	}
+
+	// Una sustitución no tiene contadores: se anula sin cambiar el partido
+	rec = s.do(http.MethodPost, void(events[2].ID), reason)
+	expectStatus(t, rec, http.StatusOK)
+	if sub := decode[MatchCorrection](t, rec); len(sub.Changes) != 0 || sub.Match.Version != got.Match.Version {
+		t.Errorf("la anulación de la sustitución retornó %+v", sub)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		body   any
+		status int
+		code   string
+	}{
+		{"anulado dos veces", void(events[0].ID), reason, http.StatusConflict, codeEventAlreadyVoided},
+		{"evento desconocido", void(999), reason, http.StatusNotFound, codeEventNotFound},
+		{"id de evento inválido", fmt.Sprintf("/api/matches/%d/events/x/void", m.ID), reason, http.StatusBadRequest, codeInvalidID},
+		{"sin motivo", void(events[1].ID), map[string]string{}, http.StatusBadRequest, codeValidationFailed},
+		{"motivo en blanco", void(events[1].ID), map[string]string{"reason": " "}, http.StatusBadRequest, codeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectProblem(t, s.do(http.MethodPost, tt.target, tt.body), tt.status, tt.code)
+		})
+	}
+
+	timeline := decode[[]MatchEvent](t, s.do(http.MethodGet, fmt.Sprintf("/api/matches/%d/events", m.ID), nil))
+	var voided []bool
+	for _, e := range timeline {
+		voided = append(voided, e.VoidedAt != nil)
+	}
+	if want := []bool{true, false, true}; !slices.Equal(voided, want) {
+		t.Errorf("anulados %v en la cronología, se esperaba %v", voided, want)
+	}
+}
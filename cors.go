@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// corsSettings define la política CORS. RouteMethods usa como clave la ruta
+// tal como se registra en gin, por ejemplo "/api/matches/:id".
+type corsSettings struct {
+	AllowedOrigins   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+	RouteMethods     map[string][]string
+}
+
+// validateOrigins verifica que cada origen sea "*", un origen completo como
+// https://laliga.com o un comodín de subdominio como https://*.laliga.com
+func validateOrigins(origins []string) error {
+	for _, origin := range origins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(strings.Replace(origin, "*.", "comodin.", 1))
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return fmt.Errorf("origen inválido %q, use por ejemplo https://laliga.com o https://*.laliga.com", origin)
+		}
+		if strings.Count(origin, "*") > 1 || (strings.Contains(origin, "*") && !strings.Contains(origin, "://*.")) {
+			return fmt.Errorf("origen inválido %q, el comodín solo se admite como primer subdominio", origin)
+		}
+	}
+	return nil
+}
+
+// parseRouteMethods interpreta "/api/matches=GET;/api/matches/:id=GET,PUT"
+func parseRouteMethods(routes string) (map[string][]string, error) {
+	perRoute := map[string][]string{}
+	if routes == "" {
+		return perRoute, nil
+	}
+	for _, entry := range strings.Split(routes, ";") {
+		route, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || !strings.HasPrefix(route, "/") {
+			return nil, fmt.Errorf("entrada inválida %q, use \"/ruta=GET,POST\"", entry)
+		}
+		var methods []string
+		for _, m := range strings.Split(value, ",") {
+			if m = strings.ToUpper(strings.TrimSpace(m)); m != "" {
+				methods = append(methods, m)
+			}
+		}
+		if len(methods) == 0 {
+			return nil, fmt.Errorf("la ruta %q no tiene métodos", route)
+		}
+		perRoute[strings.TrimSpace(route)] = methods
+	}
+	return perRoute, nil
+}
+
+// corsPolicy aplica corsSettings. Los métodos de cada ruta se aprenden de las
+// rutas registradas en gin salvo que la configuración los restrinja.
+type corsPolicy struct {
+	settings corsSettings
+	routes   map[string][]string
+}
+
+func newCORSPolicy(s corsSettings) *corsPolicy {
+	return &corsPolicy{settings: s, routes: map[string][]string{}}
+}
+
+// learnRoutes registra los métodos de cada ruta; se llama después de definir
+// todas las rutas del router
+func (p *corsPolicy) learnRoutes(routes gin.RoutesInfo) {
+	for _, r := range routes {
+		if r.Method == http.MethodOptions || slices.Contains(p.routes[r.Path], r.Method) {
+			continue
+		}
+		p.routes[r.Path] = append(p.routes[r.Path], r.Method)
+	}
+	for path, methods := range p.settings.RouteMethods {
+		p.routes[path] = methods
+	}
+}
+
+// methodsFor busca la ruta que corresponde a path y retorna sus métodos.
+// Si varias coinciden gana la más específica, como hace gin.
+func (p *corsPolicy) methodsFor(path string) []string {
+	if methods, ok := p.routes[path]; ok {
+		return methods
+	}
+	var best []string
+	bestParams := -1
+	for pattern, methods := range p.routes {
+		if !matchRoute(pattern, path) {
+			continue
+		}
+		params := strings.Count(pattern, ":") + strings.Count(pattern, "*")
+		if bestParams == -1 || params < bestParams {
+			best, bestParams = methods, params
+		}
+	}
+	return best
+}
+
+// matchRoute compara una ruta concreta con un patrón de gin (":param", "*resto")
+func matchRoute(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if strings.HasPrefix(part, ":") {
+			if pathParts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
+
+func (p *corsPolicy) originAllowed(origin string) bool {
+	for _, allowed := range p.settings.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+		prefix, suffix, ok := strings.Cut(allowed, "*.")
+		if !ok || !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, "."+suffix) {
+			continue
+		}
+		subdomain := origin[len(prefix) : len(origin)-len(suffix)-1]
+		if subdomain != "" && !strings.ContainsAny(subdomain, "/:@") {
+			return true
+		}
+	}
+	return false
+}
+
+// middleware agrega las cabeceras CORS y responde los preflight
+func (p *corsPolicy) middleware() gin.HandlerFunc {
+	allowedHeaders := strings.Join(p.settings.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(p.settings.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(p.settings.MaxAge.Seconds()))
+	wildcard := slices.Contains(p.settings.AllowedOrigins, "*") && !p.settings.AllowCredentials
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		c.Writer.Header().Add("Vary", "Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !p.originAllowed(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			// Sin cabeceras CORS el navegador bloquea la respuesta
+			c.Next()
+			return
+		}
+
+		var methods []string
+		if preflight {
+			methods = p.methodsFor(c.Request.URL.Path)
+			requested := strings.ToUpper(c.GetHeader("Access-Control-Request-Method"))
+			if !slices.Contains(methods, requested) {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+		}
+
+		h := c.Writer.Header()
+		if wildcard {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if p.settings.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if exposedHeaders != "" {
+				h.Set("Access-Control-Expose-Headers", exposedHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if allowedHeaders != "" {
+			h.Set("Access-Control-Allow-Headers", allowedHeaders)
+		}
+		h.Set("Access-Control-Max-Age", maxAge)
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// poolSettings agrupa los parámetros configurables del pool de conexiones
+type poolSettings struct {
+	MinConns          int32
+	MaxConns          int32
+	MaxConnIdleTime   time.Duration
+	MaxConnLifetime   time.Duration
+	HealthCheckPeriod time.Duration
+}
+
+// PoolStats expone el estado actual del pool de conexiones
+// @Description Estadísticas del pool de conexiones a PostgreSQL
+type PoolStats struct {
+	TotalConns           int32  `json:"totalConns"`
+	IdleConns            int32  `json:"idleConns"`
+	AcquiredConns        int32  `json:"acquiredConns"`
+	ConstructingConns    int32  `json:"constructingConns"`
+	MaxConns             int32  `json:"maxConns"`
+	AcquireCount         int64  `json:"acquireCount"`
+	EmptyAcquireCount    int64  `json:"emptyAcquireCount"`
+	CanceledAcquireCount int64  `json:"canceledAcquireCount"`
+	AcquireDuration      string `json:"acquireDuration"`
+	NewConnsCount        int64  `json:"newConnsCount"`
+	IdleDestroyCount     int64  `json:"idleDestroyCount"`
+	LifetimeDestroyCount int64  `json:"lifetimeDestroyCount"`
+}
+
+func initDB(cfg DatabaseConfig) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(cfg.ConnString())
+	if err != nil {
+		return nil, fmt.Errorf("configuración de conexión inválida: %v", err)
+	}
+	config.MinConns = cfg.Pool.MinConns
+	config.MaxConns = cfg.Pool.MaxConns
+	config.MaxConnIdleTime = cfg.Pool.MaxConnIdleTime
+	config.MaxConnLifetime = cfg.Pool.MaxConnLifetime
+	config.HealthCheckPeriod = cfg.Pool.HealthCheckPeriod
+
+	var pool *pgxpool.Pool
+
+	for i := 0; i < 5; i++ {
+		ctx := context.Background()
+		pool, err = pgxpool.NewWithConfig(ctx, config)
+		if err == nil {
+			err = pool.Ping(ctx)
+			if err == nil {
+				break
+			}
+			pool.Close()
+		}
+		if i < 4 {
+			time.Sleep(time.Duration(i*i) * time.Second)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo conectar a la base de datos después de 5 intentos: %v", err)
+	}
+
+	return pool, nil
+}
+
+// poolStats godoc
+// @Summary Estadísticas del pool de conexiones
+// @Description Retorna el estado del pool de conexiones a la base de datos para operadores
+// @Tags admin
+// @Produce json
+// @Success 200 {object} PoolStats
+// @Failure 503 {object} Problem
+// @Router /admin/pool [get]
+func (a *app) poolStats(c *gin.Context) {
+	if a.pool == nil {
+		respondProblem(c, codePoolUnavailable, tr(c, "detail.no_pool"))
+		return
+	}
+
+	s := a.pool.Stat()
+	c.IndentedJSON(http.StatusOK, PoolStats{
+		TotalConns:           s.TotalConns(),
+		IdleConns:            s.IdleConns(),
+		AcquiredConns:        s.AcquiredConns(),
+		ConstructingConns:    s.ConstructingConns(),
+		MaxConns:             s.MaxConns(),
+		AcquireCount:         s.AcquireCount(),
+		EmptyAcquireCount:    s.EmptyAcquireCount(),
+		CanceledAcquireCount: s.CanceledAcquireCount(),
+		AcquireDuration:      s.AcquireDuration().String(),
+		NewConnsCount:        s.NewConnsCount(),
+		IdleDestroyCount:     s.MaxIdleDestroyCount(),
+		LifetimeDestroyCount: s.MaxLifetimeDestroyCount(),
+	})
+}
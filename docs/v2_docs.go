@@ -0,0 +1,683 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplatev2 = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {
+            "name": "Soporte API",
+            "email": "soporte@sebastian_laliga.com"
+        },
+        "license": {
+            "name": "MIT"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/matches": {
+            "get": {
+                "description": "Mismos filtros y paginación que GET /api/matches; la página viene en data y el total en total.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches-v2"
+                ],
+                "summary": "Listar partidos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Equipo local o visitante (contiene, sin distinguir mayúsculas)",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del equipo local o visitante",
+                        "name": "teamId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Equipo local",
+                        "name": "homeTeam",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Equipo visitante",
+                        "name": "awayTeam",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha mínima (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha máxima (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "live",
+                            "finished"
+                        ],
+                        "type": "string",
+                        "description": "Estado del partido",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "date",
+                            "-date"
+                        ],
+                        "type": "string",
+                        "description": "Orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tamaño de página",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Partidos a omitir",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor de la cabecera Link",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag de una respuesta anterior",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.MatchListV2"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Cambia si cambia la página"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "Páginas first, prev, next y last"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "La página no cambió"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "kickoff es la hora de inicio en RFC 3339 y es opcional. Si se envía, date se puede omitir\ny debe ser la fecha de kickoff en la zona horaria indicada.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches-v2"
+                ],
+                "summary": "Crear un partido",
+                "parameters": [
+                    {
+                        "description": "Datos del partido",
+                        "name": "match",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.matchRequestV2"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única de la operación para reintentarla sin duplicarla",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/main.MatchV2"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL del partido creado"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches-v2"
+                ],
+                "summary": "Obtener un partido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Partido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag de una respuesta anterior",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.MatchV2"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del partido"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "El partido no cambió"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Reemplaza equipos, fecha y hora de inicio; las estadísticas no cambian. Acepta If-Match como PUT /api/matches/{id}.\nSin kickoff se conserva la hora de inicio anterior si la fecha no cambia y se borra si cambia.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches-v2"
+                ],
+                "summary": "Reemplazar los datos de un partido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Partido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos del partido",
+                        "name": "match",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.matchRequestV2"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag leído del partido",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.MatchV2"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "matches-v2"
+                ],
+                "summary": "Eliminar un partido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Partido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag leído del partido",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "main.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.MatchListV2": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.MatchV2"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.MatchV2": {
+            "description": "Partido con su estado y estadísticas",
+            "type": "object",
+            "properties": {
+                "awayTeam": {
+                    "$ref": "#/definitions/main.TeamRefV2"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "homeTeam": {
+                    "$ref": "#/definitions/main.TeamRefV2"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kickoff": {
+                    "type": "string",
+                    "example": "2025-04-01T19:00:00Z"
+                },
+                "score": {
+                    "$ref": "#/definitions/main.ScoreV2"
+                },
+                "stats": {
+                    "$ref": "#/definitions/main.StatsV2"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "live",
+                        "finished"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.Problem": {
+            "description": "Error con formato application/problem+json",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ScoreV2": {
+            "type": "object",
+            "properties": {
+                "away": {
+                    "type": "integer"
+                },
+                "home": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string",
+                    "enum": [
+                        "home",
+                        "draw",
+                        "away"
+                    ]
+                }
+            }
+        },
+        "main.StatsV2": {
+            "type": "object",
+            "properties": {
+                "extraTime": {
+                    "type": "integer"
+                },
+                "goals": {
+                    "type": "integer"
+                },
+                "redCards": {
+                    "type": "integer"
+                },
+                "yellowCards": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.TeamRefV2": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.matchRequestV2": {
+            "type": "object",
+            "required": [
+                "awayTeamId",
+                "homeTeamId"
+            ],
+            "properties": {
+                "awayTeamId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "homeTeamId": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "kickoff": {
+                    "type": "string",
+                    "example": "2025-04-01T21:00:00+02:00"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfov2 holds exported Swagger Info so clients can modify it
+var SwaggerInfov2 = &swag.Spec{
+	Version:          "2.0",
+	Host:             "localhost:8080",
+	BasePath:         "/api/v2",
+	Schemes:          []string{},
+	Title:            "LaLigaTracker API v2",
+	Description:      "Partido con su estado y estadísticas",
+	InfoInstanceName: "v2",
+	SwaggerTemplate:  docTemplatev2,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfov2.InstanceName(), SwaggerInfov2)
+}
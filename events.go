@@ -0,0 +1,253 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Tipos de evento de la cronología de un partido
+const (
+	eventGoal         = "goal"
+	eventYellowCard   = "yellow_card"
+	eventRedCard      = "red_card"
+	eventSubstitution = "substitution"
+	eventExtraTime    = "extra_time" // un minuto más de tiempo extra
+)
+
+// MatchEventInput son los datos de un evento nuevo
+type MatchEventInput struct {
+	MatchID   int
+	Type      string
+	Minute    *int // nil en los eventos registrados sin minuto
+	AddedTime int  // minuto dentro del tiempo añadido, por ejemplo 90+3
+	Team      string
+	Player    string
+	PlayerID  int // 0 si el evento no se atribuye a un jugador de /api/teams/{id}/players
+}
+
+// MatchEventVoid indica el evento a anular. Con EventID en 0 se anula el
+// último evento vigente de Type (y de Team si se indica); si no hay ninguno,
+// por ejemplo porque el contador es anterior a la cronología, se descuenta
+// el contador y se agrega el evento ya anulado.
+type MatchEventVoid struct {
+	MatchID int
+	EventID int
+	Type    string
+	Team    string
+	Reason  string
+}
+
+// MatchEvent es un evento de la cronología de un partido
+// @Description Gol, tarjeta, sustitución o cambio del tiempo extra. Un evento anulado tiene voidedAt y voidReason.
+type MatchEvent struct {
+	ID         int        `json:"id"`
+	MatchID    int        `json:"matchId"`
+	Type       string     `json:"type" enums:"goal,yellow_card,red_card,substitution,extra_time"`
+	Minute     *int       `json:"minute,omitempty"`
+	AddedTime  int        `json:"addedTime,omitempty"`
+	Team       string     `json:"team,omitempty" enums:"home,away"`
+	Player     string     `json:"player,omitempty"`
+	PlayerID   int        `json:"playerId,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	VoidedAt   *time.Time `json:"voidedAt,omitempty"`
+	VoidReason string     `json:"voidReason,omitempty"`
+}
+
+// counter es un contador de un partido que cambia con los eventos
+type counter struct {
+	name  string // nombre del campo en JSON
+	value *int
+}
+
+// eventCounters retorna los contadores de m que cuenta un evento; es vacío
+// para las sustituciones. Un gol sin equipo, como los registrados antes de
+// separar el marcador, solo cuenta en goals.
+func eventCounters(m *Match, eventType, team string) []counter {
+	switch eventType {
+	case eventGoal:
+		switch team {
+		case sideHome:
+			return []counter{{"goals", &m.Goals}, {"homeScore", &m.HomeScore}}
+		case sideAway:
+			return []counter{{"goals", &m.Goals}, {"awayScore", &m.AwayScore}}
+		}
+		return []counter{{"goals", &m.Goals}}
+	case eventYellowCard:
+		return []counter{{"yellowCards", &m.YellowCards}}
+	case eventRedCard:
+		return []counter{{"redCards", &m.RedCards}}
+	case eventExtraTime:
+		return []counter{{"extraTime", &m.ExtraTime}}
+	}
+	return nil
+}
+
+// MatchEventResponse es la respuesta al registrar un evento
+type MatchEventResponse struct {
+	Event MatchEvent `json:"event"`
+	Match Match      `json:"match"`
+}
+
+// eventDetails son los datos opcionales de un evento que aceptan tanto
+// POST /api/matches/{id}/events como las rutas de contadores
+type eventDetails struct {
+	Minute    *int   `json:"minute" binding:"omitempty,min=0,max=120"`
+	AddedTime int    `json:"addedTime" binding:"min=0,max=30"`
+	Player    string `json:"player" binding:"max=255"`
+	PlayerID  int    `json:"playerId" binding:"omitempty,min=1"`
+}
+
+// eventRequest es el cuerpo de POST /api/matches/{id}/events
+type eventRequest struct {
+	Type string `json:"type" binding:"required,oneof=goal yellow_card red_card substitution extra_time"`
+	Team string `json:"team" binding:"omitempty,oneof=home away"`
+	eventDetails
+}
+
+// cardRequest es el cuerpo opcional de las rutas de tarjetas
+type cardRequest struct {
+	Team string `json:"team" binding:"omitempty,oneof=home away"`
+	eventDetails
+}
+
+// input arma el evento de matchID con los datos de d
+func (d eventDetails) input(matchID int, eventType, team string) MatchEventInput {
+	return MatchEventInput{MatchID: matchID, Type: eventType, Minute: d.Minute, AddedTime: d.AddedTime, Team: team, Player: d.Player, PlayerID: d.PlayerID}
+}
+
+// bindOptionalJSON es como ShouldBindJSON pero acepta un cuerpo vacío, para
+// que los clientes que no envían detalles del evento sigan funcionando
+func bindOptionalJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil && !errors.Is(err, io.EOF) {
+		respondBindError(c, err)
+		return false
+	}
+	return true
+}
+
+// eventFieldErrors valida las reglas que dependen del tipo de evento: los
+// goles y las sustituciones indican el equipo o el jugador y el tiempo extra
+// no indica ninguno
+func eventFieldErrors(c *gin.Context, in MatchEventInput) []FieldError {
+	switch {
+	case (in.Type == eventGoal || in.Type == eventSubstitution) && in.Team == "" && in.PlayerID == 0:
+		return []FieldError{{Field: "team", Code: fieldRequired, Message: tr(c, "field.event_team_required")}}
+	case in.Type == eventExtraTime && in.Team != "":
+		return []FieldError{{Field: "team", Code: fieldInvalidValue, Message: tr(c, "field.event_team_forbidden")}}
+	case in.Type == eventExtraTime && in.PlayerID != 0:
+		return []FieldError{{Field: "playerId", Code: fieldInvalidValue, Message: tr(c, "field.event_team_forbidden")}}
+	case in.AddedTime > 0 && in.Minute == nil:
+		return []FieldError{{Field: "addedTime", Code: fieldInvalidValue, Message: tr(c, "field.event_minute_required")}}
+	}
+	return nil
+}
+
+// eventCounter es el contador de métricas de cada tipo de evento
+func (m *metrics) eventCounter(eventType string) *prometheus.CounterVec {
+	switch eventType {
+	case eventGoal:
+		return m.goals
+	case eventYellowCard:
+		return m.yellowCards
+	case eventRedCard:
+		return m.redCards
+	case eventExtraTime:
+		return m.extraTime
+	}
+	return nil
+}
+
+// addEvent valida y registra el evento; responde el error y retorna false
+// si no se pudo registrar
+func (a *app) addEvent(c *gin.Context, in MatchEventInput) (MatchEvent, Match, bool) {
+	if fields := eventFieldErrors(c, in); len(fields) > 0 {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"), fields...)
+		return MatchEvent{}, Match{}, false
+	}
+	event, match, err := a.store.AddMatchEvent(c.Request.Context(), in)
+	if err != nil {
+		respondStoreError(c, err)
+		return MatchEvent{}, Match{}, false
+	}
+	if counter := a.metrics.eventCounter(event.Type); counter != nil {
+		countEvent(counter, match)
+	}
+	return event, match, true
+}
+
+// listMatchEvents godoc
+// @Summary Cronología de un partido
+// @Description Retorna los goles, tarjetas, sustituciones y cambios del tiempo extra en el orden en que se registraron.
+// @Description Los eventos registrados por las rutas de contadores sin indicar el minuto no tienen minute.
+// @Tags matches
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Success 200 {array} MatchEvent
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id}/events [get]
+func (a *app) listMatchEvents(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+	events, err := a.store.ListMatchEvents(c.Request.Context(), matchID)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, events)
+}
+
+// createMatchEvent godoc
+// @Summary Registrar un evento
+// @Description Agrega un evento a la cronología y actualiza el contador del partido en la misma operación:
+// @Description goal suma al marcador de team, yellow_card y red_card a las tarjetas, extra_time un minuto de tiempo extra
+// @Description y substitution no cambia contadores. goal y substitution requieren team o playerId; extra_time no admite ninguno.
+// @Description Con playerId el evento se atribuye a un jugador de la plantilla de uno de los equipos en la temporada del partido,
+// @Description team se completa con el lado de su equipo y player con su nombre.
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Param event body eventRequest true "Evento"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 201 {object} MatchEventResponse
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id}/events [post]
+func (a *app) createMatchEvent(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+	var req eventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if req.Minute == nil {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"),
+			FieldError{Field: "minute", Code: fieldRequired, Message: tr(c, "validation.required")})
+		return
+	}
+	event, match, ok := a.addEvent(c, req.input(matchID, req.Type, req.Team))
+	if !ok {
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, MatchEventResponse{Event: event, Match: match})
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// counters son los contadores de un partido en el orden goals, homeScore,
+// awayScore, yellowCards, redCards y extraTime
+func counters(m Match) []int {
+	return []int{m.Goals, m.HomeScore, m.AwayScore, m.YellowCards, m.RedCards, m.ExtraTime}
+}
+
+func TestCreateMatchEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		team     string
+		counters []int
+	}{
+		{"gol en el tiempo añadido", `{"type":"goal","minute":90,"addedTime":3,"team":"away","player":"Vinícius"}`, sideAway, []int{1, 0, 1, 0, 0, 0}},
+		{"amarilla sin equipo", `{"type":"yellow_card","minute":30}`, "", []int{0, 0, 0, 1, 0, 0}},
+		{"roja del local", `{"type":"red_card","minute":0,"team":"home"}`, sideHome, []int{0, 0, 0, 0, 1, 0}},
+		{"sustitución", `{"type":"substitution","minute":60,"team":"home"}`, sideHome, []int{0, 0, 0, 0, 0, 0}},
+		{"tiempo extra", `{"type":"extra_time","minute":45}`, "", []int{0, 0, 0, 0, 0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			m := s.match(s.team("Barcelona"), s.team("Real Madrid"), "2025-04-01")
+
+			rec := s.do(http.MethodPost, fmt.Sprintf("/api/matches/%d/events", m.ID), tt.body)
+			expectStatus(t, rec, http.StatusCreated)
+			got := decode[MatchEventResponse](t, rec)
+			if got.Event.ID == 0 || got.Event.MatchID != m.ID || got.Event.Minute == nil || got.Event.Team != tt.team {
+				t.Errorf("evento %+v", got.Event)
+			}
+			if !slices.Equal(counters(got.Match), tt.counters) {
+				t.Errorf("contadores %v, se esperaba %v", counters(got.Match), tt.counters)
+			}
+			stored, err := s.store.GetMatch(t.Context(), m.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(counters(stored), tt.counters) || stored.Version != got.Match.Version {
+				t.Errorf("el partido guardado %+v no coincide con la respuesta %+v", stored, got.Match)
+			}
+		})
+	}
+}
+
+func TestCreateMatchEventErrors(t *testing.T) {
+	s := newTestServer(t)
+	m := s.match(s.team("Barcelona"), s.team("Real Madrid"), "2025-04-01")
+	events := fmt.Sprintf("/api/matches/%d/events", m.ID)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		code   string
+		field  string
+	}{
+		{"sin minuto", http.MethodPost, events, `{"type":"goal","team":"home"}`, http.StatusBadRequest, codeValidationFailed, "minute"},
+		{"tipo desconocido", http.MethodPost, events, `{"type":"corner","minute":10}`, http.StatusBadRequest, codeValidationFailed, "type"},
+		{"minuto fuera de rango", http.MethodPost, events, `{"type":"goal","minute":121,"team":"home"}`, http.StatusBadRequest, codeValidationFailed, "minute"},
+		{"gol sin equipo", http.MethodPost, events, `{"type":"goal","minute":10}`, http.StatusBadRequest, codeValidationFailed, "team"},
+		{"sustitución sin equipo", http.MethodPost, events, `{"type":"substitution","minute":10}`, http.StatusBadRequest, codeValidationFailed, "team"},
+		{"tiempo extra con equipo", http.MethodPost, events, `{"type":"extra_time","minute":45,"team":"home"}`, http.StatusBadRequest, codeValidationFailed, "team"},
+		{"tiempo extra con jugador", http.MethodPost, events, `{"type":"extra_time","minute":45,"playerId":1}`, http.StatusBadRequest, codeValidationFailed, "playerId"},
+		{"tiempo añadido sin minuto", http.MethodPatch, fmt.Sprintf("/api/matches/%d/yellowcards", m.ID), `{"addedTime":2}`, http.StatusBadRequest, codeValidationFailed, "addedTime"},
+		{"partido desconocido", http.MethodPost, "/api/matches/999/events", `{"type":"goal","minute":10,"team":"home"}`, http.StatusNotFound, codeMatchNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := expectProblem(t, s.do(tt.method, tt.target, tt.body), tt.status, tt.code)
+			if tt.field != "" && (len(p.Errors) != 1 || p.Errors[0].Field != tt.field) {
+				t.Errorf("errores %+v, se esperaba el campo %s", p.Errors, tt.field)
+			}
+		})
+	}
+
+	got, err := s.store.ListMatchEvents(t.Context(), m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("los eventos rechazados se agregaron a la cronología: %+v", got)
+	}
+}
+
+// TestCounterRoutesRecordEvents verifica que las rutas de contadores agreguen
+// su evento a la cronología, con o sin cuerpo
+func TestCounterRoutesRecordEvents(t *testing.T) {
+	s := newTestServer(t)
+	m := s.match(s.team("Barcelona"), s.team("Real Madrid"), "2025-04-01")
+	requests := []struct {
+		route string
+		body  any
+	}{
+		{"goals", map[string]any{"side": sideHome, "minute": 12, "player": "Lewandowski"}},
+		{"yellowcards", nil},
+		{"redcards", map[string]any{"team": sideAway, "minute": 80}},
+		{"extratime", nil},
+		{"extratime", map[string]any{"minute": 90}},
+	}
+	for _, r := range requests {
+		expectStatus(t, s.do(http.MethodPatch, fmt.Sprintf("/api/matches/%d/%s", m.ID, r.route), r.body), http.StatusOK)
+	}
+
+	rec := s.do(http.MethodGet, fmt.Sprintf("/api/matches/%d/events", m.ID), nil)
+	expectStatus(t, rec, http.StatusOK)
+	var types, teams []string
+	var minutes []int
+	for _, e := range decode[[]MatchEvent](t, rec) {
+		types, teams = append(types, e.Type), append(teams, e.Team)
+		minute := -1
+		if e.Minute != nil {
+			minute = *e.Minute
+		}
+		minutes = append(minutes, minute)
+	}
+	if want := []string{eventGoal, eventYellowCard, eventRedCard, eventExtraTime, eventExtraTime}; !slices.Equal(types, want) {
+		t.Errorf("tipos %v, se esperaba %v", types, want)
+	}
+	if want := []string{sideHome, "", sideAway, "", ""}; !slices.Equal(teams, want) {
+		t.Errorf("equipos %v, se esperaba %v", teams, want)
+	}
+	if want := []int{12, -1, 80, -1, 90}; !slices.Equal(minutes, want) {
+		t.Errorf("minutos %v, se esperaba %v (-1 sin minuto)", minutes, want)
+	}
+
+	got, err := s.store.GetMatch(t.Context(), m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 1, 0, 1, 1, 2}; !slices.Equal(counters(got), want) {
+		t.Errorf("contadores %v, se esperaba %v", counters(got), want)
+	}
+
+	expectProblem(t, s.do(http.MethodGet, "/api/matches/999/events", nil), http.StatusNotFound, codeMatchNotFound)
+}
+
+func TestExtraTimeLimit(t *testing.T) {
+	s := newTestServer(t)
+	m := s.match(s.team("Barcelona"), s.team("Real Madrid"), "2025-04-01")
+	m.ExtraTime = maxExtraTime - 1
+	if _, err := s.store.ReplaceMatch(t.Context(), m); err != nil {
+		t.Fatal(err)
+	}
+	target := fmt.Sprintf("/api/matches/%d/extratime", m.ID)
+
+	type extraTimeResponse struct {
+		ExtraTime int         `json:"extraTime"`
+		Event     *MatchEvent `json:"event"`
+	}
+	rec := s.do(http.MethodPatch, target, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if got := decode[extraTimeResponse](t, rec); got.ExtraTime != maxExtraTime || got.Event == nil {
+		t.Errorf("respuesta %+v, se esperaba el tiempo extra en %d con su evento", got, maxExtraTime)
+	}
+
+	// En el máximo la ruta de contadores responde 200 sin agregar el evento
+	rec = s.do(http.MethodPatch, target, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if got := decode[extraTimeResponse](t, rec); got.ExtraTime != maxExtraTime || got.Event != nil {
+		t.Errorf("respuesta %+v en el máximo, se esperaba %d sin evento", got, maxExtraTime)
+	}
+	expectProblem(t, s.do(http.MethodPost, fmt.Sprintf("/api/matches/%d/events", m.ID), `{"type":"extra_time","minute":90}`),
+		http.StatusConflict, codeExtraTimeLimit)
+
+	events, err := s.store.ListMatchEvents(t.Context(), m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Errorf("%d eventos, se esperaba 1", len(events))
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Se sobrescriben al compilar con
+// -ldflags "-X main.gitCommit=... -X main.buildTime=..."
+var (
+	gitCommit = ""
+	buildTime = ""
+)
+
+var startTime = time.Now()
+
+// HealthStatus es la respuesta del endpoint de liveness
+// @Description Estado del proceso
+type HealthStatus struct {
+	Status string `json:"status" example:"ok"`
+}
+
+// CheckResult es el resultado de una verificación de readiness
+// @Description Resultado de una verificación individual
+type CheckResult struct {
+	Status  string `json:"status" example:"ok"`
+	Latency string `json:"latency,omitempty" example:"1.2ms"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SchemaCheck es el resultado de comparar la versión del esquema
+// @Description Versión del esquema esperada y encontrada
+type SchemaCheck struct {
+	Status   string `json:"status" example:"ok"`
+	Expected int    `json:"expected" example:"1"`
+	Current  int    `json:"current" example:"1"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ReadinessStatus es la respuesta del endpoint de readiness
+// @Description Estado de las dependencias necesarias para atender peticiones
+type ReadinessStatus struct {
+	Status   string      `json:"status" example:"ready"`
+	Database CheckResult `json:"database"`
+	Schema   SchemaCheck `json:"schema"`
+	// RequestID solo se incluye cuando la API no está lista
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// BuildInfo describe la versión del binario en ejecución
+// @Description Información de compilación y tiempo en ejecución
+type BuildInfo struct {
+	Version   string    `json:"version" example:"1.0"`
+	GitCommit string    `json:"gitCommit" example:"c6f1ca7"`
+	BuildTime string    `json:"buildTime" example:"2025-04-01T12:00:00Z"`
+	GoVersion string    `json:"goVersion" example:"go1.24.1"`
+	StartedAt time.Time `json:"startedAt"`
+	Uptime    string    `json:"uptime" example:"3h25m10s"`
+}
+
+// buildInfo completa los datos no inyectados con -ldflags a partir de la
+// información de VCS que Go incrusta al compilar dentro del repositorio
+func buildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   "1.0",
+		GitCommit: gitCommit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+		StartedAt: startTime,
+		Uptime:    time.Since(startTime).Round(time.Second).String(),
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.GitCommit == "":
+				info.GitCommit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildTime == "":
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+	if info.GitCommit == "" {
+		info.GitCommit = "desconocido"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "desconocido"
+	}
+	return info
+}
+
+// liveness godoc
+// @Summary Liveness
+// @Description Indica que el proceso está vivo; no consulta dependencias
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthStatus
+// @Router /health [get]
+func (a *app) liveness(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, HealthStatus{Status: "ok"})
+}
+
+// readiness godoc
+// @Summary Readiness
+// @Description Verifica la conexión con la base de datos y que la versión del esquema sea la esperada
+// @Tags health
+// @Produce json
+// @Success 200 {object} ReadinessStatus
+// @Failure 503 {object} ReadinessStatus
+// @Router /health/ready [get]
+func (a *app) readiness(c *gin.Context) {
+	ctx := c.Request.Context()
+	status := ReadinessStatus{
+		Status:   "ready",
+		Database: CheckResult{Status: "ok"},
+		Schema:   SchemaCheck{Status: "ok", Expected: expectedSchemaVersion},
+	}
+
+	start := time.Now()
+	err := a.store.Ping(ctx)
+	status.Database.Latency = time.Since(start).String()
+	if err != nil {
+		// La causa queda en el log de la petición, no en la respuesta
+		c.Error(err)
+		status.Database.Status = "error"
+		status.Database.Error = tr(c, "health.database_error")
+	}
+
+	if err == nil {
+		status.Schema.Current, err = a.store.SchemaVersion(ctx)
+		if err != nil {
+			c.Error(err)
+			status.Schema.Status = "error"
+			status.Schema.Error = tr(c, "health.schema_error")
+		} else if status.Schema.Current != expectedSchemaVersion {
+			status.Schema.Status = "mismatch"
+		}
+	} else {
+		status.Schema.Status = "unknown"
+	}
+
+	if status.Database.Status != "ok" || status.Schema.Status != "ok" {
+		status.Status = "not_ready"
+		status.RequestID = requestID(c)
+		c.IndentedJSON(http.StatusServiceUnavailable, status)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
+// version godoc
+// @Summary Versión
+// @Description Retorna el commit, la fecha de compilación y el tiempo en ejecución
+// @Tags health
+// @Produce json
+// @Success 200 {object} BuildInfo
+// @Router /version [get]
+func (a *app) version(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, buildInfo())
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// defaultLanguage es el idioma de respaldo: se usa cuando el cliente no pide
+// un idioma disponible y para las claves que falten en otro catálogo
+const defaultLanguage = "es"
+
+// langKey es la clave de gin.Context con el idioma negociado
+const langKey = "lang"
+
+// catalogs contiene los textos de la API por idioma. Cada archivo
+// locales/<idioma>.json agrega un idioma, por ejemplo ca, eu o gl.
+var catalogs = loadCatalogs(localeFiles, "locales")
+
+// languages lista los idiomas disponibles con defaultLanguage primero, que es
+// lo que language.Matcher usa cuando no hay coincidencia
+var languages = catalogLanguages(catalogs)
+
+var languageMatcher = language.NewMatcher(languageTags(languages))
+
+func loadCatalogs(fsys fs.FS, dir string) map[string]map[string]string {
+	files, err := fs.Glob(fsys, dir+"/*.json")
+	if err != nil {
+		panic(err)
+	}
+	loaded := map[string]map[string]string{}
+	for _, file := range files {
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			panic(err)
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(b, &catalog); err != nil {
+			panic(fmt.Sprintf("catálogo %s inválido: %v", file, err))
+		}
+		loaded[strings.TrimSuffix(path.Base(file), ".json")] = catalog
+	}
+	if _, ok := loaded[defaultLanguage]; !ok {
+		panic("falta el catálogo " + defaultLanguage)
+	}
+	return loaded
+}
+
+func catalogLanguages(catalogs map[string]map[string]string) []string {
+	var langs []string
+	for lang := range catalogs {
+		if lang != defaultLanguage {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	return append([]string{defaultLanguage}, langs...)
+}
+
+func languageTags(langs []string) []language.Tag {
+	tags := make([]language.Tag, len(langs))
+	for i, lang := range langs {
+		tags[i] = language.Make(lang)
+	}
+	return tags
+}
+
+// missingTranslations retorna, por idioma, las claves del catálogo por
+// defecto que no están traducidas
+func missingTranslations() map[string][]string {
+	missing := map[string][]string{}
+	for _, lang := range languages[1:] {
+		for key := range catalogs[defaultLanguage] {
+			if _, ok := catalogs[lang][key]; !ok {
+				missing[lang] = append(missing[lang], key)
+			}
+		}
+		slices.Sort(missing[lang])
+	}
+	return missing
+}
+
+// negotiateLanguage elige el idioma de la respuesta: el parámetro lang tiene
+// prioridad sobre Accept-Language; si ninguno está disponible se usa el español
+func negotiateLanguage(c *gin.Context) string {
+	var tags []language.Tag
+	if lang := c.Query("lang"); lang != "" {
+		if tag, err := language.Parse(lang); err == nil {
+			tags = append(tags, tag)
+		}
+	}
+	accepted, _, _ := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	tags = append(tags, accepted...)
+
+	_, i, confidence := languageMatcher.Match(tags...)
+	if confidence == language.No {
+		return defaultLanguage
+	}
+	return languages[i]
+}
+
+// localize negocia el idioma de cada petición y lo indica en Content-Language
+func localize() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := negotiateLanguage(c)
+		c.Set(langKey, lang)
+		c.Header("Content-Language", lang)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// tr traduce key al idioma de la petición. Con args el texto se usa como
+// formato de fmt.Sprintf.
+func tr(c *gin.Context, key string, args ...any) string {
+	lang := c.GetString(langKey)
+	text, ok := catalogs[lang][key]
+	if !ok {
+		if text, ok = catalogs[defaultLanguage][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// hasTranslation indica si key existe en el catálogo por defecto
+func hasTranslation(key string) bool {
+	_, ok := catalogs[defaultLanguage][key]
+	return ok
+}
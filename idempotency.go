@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idempotencySettings define cuánto tiempo se guarda la respuesta de cada
+// Idempotency-Key y cada cuánto se eliminan las vencidas
+type idempotencySettings struct {
+	Retention time.Duration
+	// PurgeInterval es cada cuánto se eliminan las claves vencidas
+	PurgeInterval time.Duration
+}
+
+// maxIdempotencyKeyLength limita la clave; un UUID ocupa 36 caracteres
+const maxIdempotencyKeyLength = 255
+
+// bodyLimit es el tamaño máximo del cuerpo que idempotent lee en memoria y el
+// problema con el que se rechaza uno más grande
+type bodyLimit struct {
+	Bytes  int64
+	Code   string
+	Detail string // clave de la traducción, con el límite como argumento
+}
+
+// maxIdempotentBodyBytes limita el cuerpo de las rutas sin un límite propio
+const maxIdempotentBodyBytes = 64 << 10
+
+// defaultBodyLimit es el límite de las rutas sin uno propio; el lote usa
+// batchBodyLimit
+var defaultBodyLimit = bodyLimit{Bytes: maxIdempotentBodyBytes, Code: codeBodyTooLarge, Detail: "detail.body_too_large"}
+
+// replayedHeaders son las cabeceras de la respuesta original que se reenvían
+var replayedHeaders = []string{"Content-Type", "Content-Language", "ETag", "Location"}
+
+// idempotencyFingerprint identifica una petición por su método, ruta y
+// cuerpo. El JSON se compacta para que los espacios no cuenten.
+func idempotencyFingerprint(r *http.Request, body []byte) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err == nil {
+		body = compact.Bytes()
+	}
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter guarda una copia del cuerpo de la respuesta
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotent hace que repetir una petición con la misma Idempotency-Key
+// reenvíe la respuesta guardada en lugar de ejecutarla otra vez, por ejemplo
+// cuando un cliente reintenta un gol tras un timeout. Sin la cabecera la
+// petición se atiende normalmente.
+//
+// El cuerpo se lee hasta limit.Bytes; si lo supera se responde 413 sin
+// reservar la clave. Las respuestas 5xx no se guardan para que el cliente
+// pueda reintentar.
+func (a *app) idempotent(limit bodyLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			respondProblem(c, codeInvalidIdempotencyKey, tr(c, "detail.idempotency_key_length", maxIdempotencyKeyLength))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, limit.Bytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondProblem(c, limit.Code, tr(c, limit.Detail, tooLarge.Limit))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			respondProblem(c, codeMalformedBody, tr(c, "detail.unreadable_body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		rec := IdempotencyRecord{Key: key, Fingerprint: idempotencyFingerprint(c.Request, body)}
+		stored, reserved, err := a.store.ReserveIdempotencyKey(ctx, rec, time.Now().Add(-a.idempotency.Retention))
+		if err != nil {
+			respondStoreError(c, err)
+			return
+		}
+		if !reserved {
+			switch {
+			case stored.Fingerprint != rec.Fingerprint:
+				respondProblem(c, codeIdempotencyKeyReused, tr(c, "detail.idempotency_key_reused"))
+			case stored.Status == 0:
+				respondProblem(c, codeIdempotencyKeyInUse, tr(c, "detail.idempotency_key_in_use"))
+			default:
+				for name, values := range stored.Header {
+					for _, v := range values {
+						c.Writer.Header().Add(name, v)
+					}
+				}
+				c.Header("Idempotent-Replayed", "true")
+				c.Abort()
+				c.Status(stored.Status)
+				c.Writer.Write(stored.Body)
+			}
+			return
+		}
+
+		w := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		defer func() {
+			// Si el handler entra en pánico la clave se libera y el pánico
+			// sigue hasta recoverPanic, que responde 500
+			p := recover()
+
+			// La respuesta ya se envió; se guarda aunque el cliente se haya ido
+			ctx := context.WithoutCancel(ctx)
+			var err error
+			if status := w.Status(); p != nil || status >= http.StatusInternalServerError || status == statusClientClosedRequest {
+				err = a.store.ReleaseIdempotencyKey(ctx, key)
+			} else {
+				rec.Status, rec.Body, rec.Header = status, w.body.Bytes(), http.Header{}
+				for _, name := range replayedHeaders {
+					if v := w.Header().Values(name); len(v) > 0 {
+						rec.Header[name] = v
+					}
+				}
+				err = a.store.CompleteIdempotencyKey(ctx, rec)
+			}
+			if err != nil {
+				c.Error(err)
+			}
+			if p != nil {
+				panic(p)
+			}
+		}()
+		c.Next()
+	}
+}
+
+// purgeIdempotencyKeys elimina cada PurgeInterval las claves que vencieron
+// hasta que ctx se cancela. Al reservar una clave solo se elimina esa clave si
+// venció, así que sin esta limpieza las demás se acumularían.
+func purgeIdempotencyKeys(ctx context.Context, store MatchStore, s idempotencySettings) {
+	ticker := time.NewTicker(s.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		purged, err := store.PurgeIdempotencyKeys(ctx, time.Now().Add(-s.Retention))
+		switch {
+		case err != nil && ctx.Err() == nil:
+			slog.Warn("no se pudieron eliminar las claves de idempotencia vencidas", "error", err)
+		case purged > 0:
+			slog.Info("claves de idempotencia vencidas eliminadas", "count", purged)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdempotencyKeyReplay(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	body := map[string]any{"homeTeamId": home.ID, "awayTeamId": away.ID, "matchDate": "2025-04-01"}
+
+	first := s.do(http.MethodPost, "/api/matches", body, "Idempotency-Key", "crear-1")
+	expectStatus(t, first, http.StatusCreated)
+	if first.Header().Get("Idempotent-Replayed") != "" {
+		t.Error("la primera respuesta no es una repetición")
+	}
+
+	// El JSON se compara compactado: los espacios no cambian la petición
+	spaced := fmt.Sprintf(`{ "awayTeamId": %d, "homeTeamId": %d, "matchDate": "2025-04-01" }`, away.ID, home.ID)
+	for _, replay := range []any{body, spaced} {
+		rec := s.do(http.MethodPost, "/api/matches", replay, "Idempotency-Key", "crear-1")
+		expectStatus(t, rec, http.StatusCreated)
+		if rec.Header().Get("Idempotent-Replayed") != "true" {
+			t.Error("la repetición no incluye Idempotent-Replayed: true")
+		}
+		if rec.Body.String() != first.Body.String() || rec.Header().Get("ETag") != first.Header().Get("ETag") {
+			t.Errorf("la repetición respondió %q, se esperaba %q", rec.Body.String(), first.Body.String())
+		}
+	}
+
+	page, err := s.store.ListMatches(t.Context(), MatchQuery{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 {
+		t.Errorf("se crearon %d partidos, se esperaba 1", page.Total)
+	}
+}
+
+func TestIdempotencyKeyErrors(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	m := s.match(home, away, "2025-04-01")
+	goals := fmt.Sprintf("/api/matches/%d/goals", m.ID)
+	expectStatus(t, s.do(http.MethodPatch, goals, map[string]string{"side": "home"}, "Idempotency-Key", "gol-1"), http.StatusOK)
+	if _, _, err := s.store.ReserveIdempotencyKey(t.Context(), IdempotencyRecord{Key: "en-curso", Fingerprint: "x"}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		key    string
+		status int
+		code   string
+	}{
+		{"otro cuerpo", http.MethodPatch, goals, map[string]string{"side": "away"}, "gol-1", http.StatusUnprocessableEntity, codeIdempotencyKeyReused},
+		{"otra ruta", http.MethodPatch, fmt.Sprintf("/api/matches/%d/yellowcards", m.ID), map[string]string{"side": "home"}, "gol-1", http.StatusUnprocessableEntity, codeIdempotencyKeyReused},
+		{"clave en curso", http.MethodPatch, goals, map[string]string{"side": "home"}, "en-curso", http.StatusUnprocessableEntity, codeIdempotencyKeyReused},
+		{"clave demasiado larga", http.MethodPatch, goals, map[string]string{"side": "home"}, strings.Repeat("k", maxIdempotencyKeyLength+1), http.StatusBadRequest, codeInvalidIdempotencyKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectProblem(t, s.do(tt.method, tt.target, tt.body, "Idempotency-Key", tt.key), tt.status, tt.code)
+		})
+	}
+
+	got, err := s.store.GetMatch(t.Context(), m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Goals != 1 || got.YellowCards != 0 {
+		t.Errorf("las peticiones rechazadas cambiaron el partido: %+v", got)
+	}
+}
+
+func TestIdempotencyKeyInUse(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	m := s.match(home, away, "2025-04-01")
+	target := fmt.Sprintf("/api/matches/%d/goals", m.ID)
+
+	// Se reserva la clave con la huella de la petición, como si la original
+	// siguiera en curso
+	req := httptest.NewRequest(http.MethodPatch, target, nil)
+	rec := IdempotencyRecord{Key: "gol-1", Fingerprint: idempotencyFingerprint(req, []byte(`{"side":"home"}`))}
+	if _, _, err := s.store.ReserveIdempotencyKey(t.Context(), rec, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	expectProblem(t, s.do(http.MethodPatch, target, map[string]string{"side": "home"}, "Idempotency-Key", "gol-1"), http.StatusConflict, codeIdempotencyKeyInUse)
+}
+
+// TestIdempotencyBodyLimit verifica que un cuerpo demasiado grande se
+// rechace antes de reservar la clave, con el límite propio de cada ruta
+func TestIdempotencyBodyLimit(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	padding := func(n int) string { return strings.Repeat(" ", n) }
+	create := fmt.Sprintf(`{"homeTeamId":%d,"awayTeamId":%d,"matchDate":"2025-04-01"}`, home.ID, away.ID)
+	batch := "[" + create + "]"
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		code   string
+	}{
+		{"partido demasiado grande", "/api/matches", create + padding(maxIdempotentBodyBytes), codeBodyTooLarge},
+		{"lote dentro de su límite", "/api/matches/batch", batch + padding(maxIdempotentBodyBytes), ""},
+		{"lote demasiado grande", "/api/matches/batch", batch + padding(maxBatchBytes), codeBatchTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(http.MethodPost, tt.target, tt.body, "Idempotency-Key", tt.name)
+			if tt.code == "" {
+				expectStatus(t, rec, http.StatusCreated)
+				return
+			}
+			expectProblem(t, rec, http.StatusRequestEntityTooLarge, tt.code)
+			// La clave no quedó reservada: la petición corregida se atiende
+			expectStatus(t, s.do(http.MethodPost, tt.target, strings.TrimSpace(tt.body), "Idempotency-Key", tt.name), http.StatusCreated)
+		})
+	}
+}
+
+func TestIdempotencyKeyExpired(t *testing.T) {
+	s := newTestServer(t, func(cfg *Config) { cfg.Idempotency.Retention = time.Nanosecond })
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	m := s.match(home, away, "2025-04-01")
+	target := fmt.Sprintf("/api/matches/%d/goals", m.ID)
+
+	for range 2 {
+		rec := s.do(http.MethodPatch, target, map[string]string{"side": "home"}, "Idempotency-Key", "gol-1")
+		expectStatus(t, rec, http.StatusOK)
+		if rec.Header().Get("Idempotent-Replayed") != "" {
+			t.Fatal("se repitió la respuesta de una clave vencida")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got, _ := s.store.GetMatch(t.Context(), m.ID); got.Goals != 2 {
+		t.Errorf("goles %d, se esperaba 2", got.Goals)
+	}
+}
+
+// TestIdempotencyReleasesKeyOnFailure verifica que una respuesta 5xx o un
+// pánico liberen la clave para que el cliente pueda reintentar
+func TestIdempotencyReleasesKeyOnFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		fail func(c *gin.Context)
+	}{
+		{"5xx", func(c *gin.Context) { respondProblem(c, codeInternal, "") }},
+		{"pánico", func(*gin.Context) { panic("falla el handler") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &app{store: newMemoryStore(), idempotency: idempotencySettings{Retention: time.Hour}}
+			calls := 0
+			router := gin.New()
+			router.Use(requestContext(slog.Default()), localize(), recoverPanic())
+			router.POST("/op", a.idempotent(defaultBodyLimit), func(c *gin.Context) {
+				if calls++; calls == 1 {
+					tt.fail(c)
+					return
+				}
+				c.JSON(http.StatusOK, gin.H{"calls": calls})
+			})
+			send := func() *httptest.ResponseRecorder {
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodPost, "/op", strings.NewReader(`{}`))
+				req.Header.Set("Idempotency-Key", "op-1")
+				router.ServeHTTP(rec, req)
+				return rec
+			}
+
+			expectProblem(t, send(), http.StatusInternalServerError, codeInternal)
+			expectStatus(t, send(), http.StatusOK)
+			if rec := send(); rec.Header().Get("Idempotent-Replayed") != "true" || calls != 2 {
+				t.Errorf("el reintento exitoso no se guardó: %d llamadas, %v", calls, rec.Header())
+			}
+		})
+	}
+}
+
+func TestPurgeIdempotencyKeys(t *testing.T) {
+	store := newMemoryStore()
+	for _, key := range []string{"a", "b"} {
+		if _, _, err := store.ReserveIdempotencyKey(t.Context(), IdempotencyRecord{Key: key}, time.Time{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		purgeIdempotencyKeys(ctx, store, idempotencySettings{Retention: time.Nanosecond, PurgeInterval: time.Millisecond})
+		close(done)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		store.mu.Lock()
+		remaining := len(store.idempotency)
+		store.mu.Unlock()
+		if remaining == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("quedan %d claves vencidas", remaining)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("purgeIdempotencyKeys no terminó al cancelar el contexto")
+	}
+}
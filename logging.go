@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestIDHeader es la cabecera con la que se propaga el id de cada petición
+const requestIDHeader = "X-Request-ID"
+
+// Claves de gin.Context usadas por los middlewares de este archivo
+const (
+	requestIDKey = "requestID"
+	loggerKey    = "logger"
+)
+
+// logSettings define el formato y el nivel de los logs
+type logSettings struct {
+	Level  slog.Level
+	Format string // json o text
+}
+
+// newLogger crea el logger del servicio según la configuración
+func newLogger(w io.Writer, s logSettings) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: s.Level}
+	if s.Format == "text" {
+		return slog.New(slog.NewTextHandler(w, opts))
+	}
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+// validRequestID acepta ids recibidos de 1 a 128 caracteres visibles para
+// evitar inyectar texto arbitrario en los logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// requestContext propaga el id de la petición y crea su logger con la ruta y
+// el partido. El id recibido en X-Request-ID se reutiliza si es válido.
+func requestContext(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+
+		reqLogger := logger.With("request_id", id, "method", c.Request.Method, "route", c.FullPath())
+		if matchID := c.Param("id"); matchID != "" {
+			reqLogger = reqLogger.With("match_id", matchID)
+		}
+		c.Set(loggerKey, reqLogger)
+
+		ctx := context.WithValue(c.Request.Context(), dbTimerKey{}, &dbTimer{})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// requestID retorna el id de la petición en curso
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// requestLogger retorna el logger de la petición en curso
+func requestLogger(c *gin.Context) *slog.Logger {
+	if logger, ok := c.Get(loggerKey); ok {
+		return logger.(*slog.Logger)
+	}
+	return slog.Default()
+}
+
+// accessLog registra cada petición al terminar, con su estado, latencia,
+// tiempo en la base de datos y los errores que la causaron
+func accessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			"status", status,
+			"path", c.Request.URL.Path,
+			"client_ip", c.ClientIP(),
+			"latency_ms", milliseconds(time.Since(start)),
+			"bytes", c.Writer.Size(),
+		}
+		if timer := dbTimerFrom(c.Request.Context()); timer != nil && timer.calls.Load() > 0 {
+			attrs = append(attrs, "db_latency_ms", milliseconds(time.Duration(timer.total.Load())), "db_calls", timer.calls.Load())
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "error", strings.Join(c.Errors.Errors(), "; "))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		requestLogger(c).Log(c.Request.Context(), level, "petición atendida", attrs...)
+	}
+}
+
+// milliseconds expresa d en milisegundos con decimales para los logs
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// recoverPanic registra los panics con el logger de la petición y responde 500
+func recoverPanic() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		requestLogger(c).Error("panic atendiendo la petición", "panic", err)
+		respondProblem(c, codeInternal, "")
+	})
+}
+
+// dbTimer acumula el tiempo que una petición pasa en la base de datos
+type dbTimer struct {
+	total atomic.Int64
+	calls atomic.Int64
+}
+
+type dbTimerKey struct{}
+
+func dbTimerFrom(ctx context.Context) *dbTimer {
+	timer, _ := ctx.Value(dbTimerKey{}).(*dbTimer)
+	return timer
+}
+
+// observeDB suma al dbTimer de ctx el tiempo transcurrido desde start
+func observeDB(ctx context.Context, start time.Time) {
+	if timer := dbTimerFrom(ctx); timer != nil {
+		timer.total.Add(int64(time.Since(start)))
+		timer.calls.Add(1)
+	}
+}
@@ -14,157 +14,229 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // Match define la estructura de un partido de fútbol
 // @Description Información completa sobre un partido de fútbol
 type Match struct {
 	ID          int       `json:"id"`
+	HomeTeamID  int       `json:"homeTeamId"`
 	HomeTeam    string    `json:"homeTeam"`
+	AwayTeamID  int       `json:"awayTeamId"`
 	AwayTeam    string    `json:"awayTeam"`
 	MatchDate   time.Time `json:"matchDate"`
 	Goals       int       `json:"goals,omitempty"`
 	YellowCards int       `json:"yellowCards,omitempty"`
 	RedCards    int       `json:"redCards,omitempty"`
 	ExtraTime   int       `json:"extraTime,omitempty"`
+	// HomeScore y AwayScore son los marcadores de cada equipo. Goals es el
+	// total e incluye los goles registrados antes de separarlos por equipo.
+	HomeScore int `json:"homeScore"`
+	AwayScore int `json:"awayScore"`
+	// Result se deriva de los marcadores; se omite si hay goles sin equipo
+	Result string `json:"result,omitempty" enums:"home,draw,away"`
+	// Kickoff es la hora de inicio, opcional; solo se publica en la API v2
+	Kickoff *time.Time `json:"-"`
+	// Version aumenta con cada cambio y junto con UpdatedAt forma el ETag
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
-var db *pgx.Conn
+// app agrupa las dependencias que usan los handlers
+type app struct {
+	store    MatchStore
+	pool     *pgxpool.Pool // nil cuando se usa el almacenamiento en memoria
+	timeouts timeoutSettings
+	cors     corsSettings
+	metrics  *metrics
+	// pagination define el tamaño de página de GET /api/matches
+	pagination paginationSettings
+	// concurrency define si las escrituras exigen If-Match
+	concurrency concurrencySettings
+	// cache define Cache-Control de las lecturas de partidos
+	cache cacheSettings
+	// idempotency define la retención de las Idempotency-Key
+	idempotency idempotencySettings
+	// versions define las cabeceras de retirada de las rutas de v1
+	versions versionSettings
+}
 
 // getMatch godoc
 // @Summary Obtener todos los partidos
-// @Description Retorna una lista de todos los partidos registrados
+// @Description Retorna una página de partidos con filtros por equipo, fechas y estado.
+// @Description La paginación es por offset o por cursor (use ?cursor= para iniciar desde el principio);
+// @Description la cabecera Link incluye las páginas vecinas y X-Total-Count el total de partidos que cumplen los filtros.
 // @Tags matches
 // @Accept json
 // @Produce json
+// @Param team query string false "Equipo local o visitante (contiene, sin distinguir mayúsculas)"
+// @Param teamId query int false "ID del equipo local o visitante"
+// @Param homeTeam query string false "Equipo local"
+// @Param awayTeam query string false "Equipo visitante"
+// @Param from query string false "Fecha mínima (YYYY-MM-DD)"
+// @Param to query string false "Fecha máxima (YYYY-MM-DD)"
+// @Param status query string false "Estado del partido" Enums(scheduled, live, finished)
+// @Param sort query string false "Orden" Enums(id, -id, date, -date)
+// @Param limit query int false "Tamaño de página"
+// @Param offset query int false "Partidos a omitir"
+// @Param cursor query string false "Cursor de la cabecera Link"
+// @Param If-None-Match header string false "ETag de una respuesta anterior"
+// @Param If-Modified-Since header string false "Last-Modified de una respuesta anterior"
 // @Success 200 {array} Match
-// @Failure 500 {object} map[string]string
+// @Header 200 {string} Link "Páginas first, prev, next y last"
+// @Header 200 {integer} X-Total-Count "Total de partidos que cumplen los filtros"
+// @Header 200 {string} ETag "Cambia si cambia la página"
+// @Header 200 {string} Last-Modified "Modificación más reciente de la página"
+// @Success 304 "La página no cambió (If-None-Match / If-Modified-Since)"
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches [get]
-func getMatch(c *gin.Context) {
-	ctx := context.Background()
-	rows, err := db.Query(ctx, `
-        SELECT id, home_team, away_team, match_date, 
-            yellow_cards, red_cards, extra_time 
-        FROM matches`)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+func (a *app) getMatch(c *gin.Context) {
+	req, fieldErr := parseListRequest(c, a.pagination, time.Now())
+	if fieldErr != nil {
+		code := codeInvalidQuery
+		if fieldErr.Code == codeInvalidDate {
+			code = codeInvalidDate
+		}
+		respondProblem(c, code, fieldErr.Error(), *fieldErr)
 		return
 	}
-	defer rows.Close()
 
-	var matches []Match
-	for rows.Next() {
-		var m Match
-		err := rows.Scan(&m.ID, &m.HomeTeam, &m.AwayTeam, &m.MatchDate,
-			&m.YellowCards, &m.RedCards, &m.ExtraTime)
-		if err != nil {
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		matches = append(matches, m)
+	// Se pide un partido extra para saber si hay una página siguiente
+	q := req.query
+	q.Limit++
+	ctx := c.Request.Context()
+	page, err := a.store.ListMatches(ctx, q)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	hasNext := len(page.Matches) > req.query.Limit
+	if hasNext {
+		page.Matches = page.Matches[:req.query.Limit]
+	}
+	if page.Matches == nil {
+		page.Matches = []Match{}
 	}
 
-	c.IndentedJSON(http.StatusOK, matches)
+	c.Header("X-Total-Count", strconv.Itoa(page.Total))
+	c.Header("Link", linkHeader(c.Request.URL, req, page, hasNext))
+	if notModified(c, listETag(page, hasNext), lastModified(page.Matches), a.cache.List) {
+		return
+	}
+	c.IndentedJSON(http.StatusOK, page.Matches)
 }
 
 // createMatch godoc
 // @Summary Crear un nuevo partido
-// @Description Crea un nuevo partido con los datos proporcionados
+// @Description Crea un nuevo partido con los datos proporcionados. Cada equipo se indica por id (homeTeamId, awayTeamId)
+// @Description o por nombre (homeTeam, awayTeam); el nombre debe corresponder al nombre, nombre corto o código de un equipo existente.
 // @Tags matches
 // @Accept json
 // @Produce json
-// @Param match body object{homeTeam=string,awayTeam=string,matchDate=string} true "Datos del partido"
+// @Param match body matchRequest true "Datos del partido"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
 // @Success 201 {object} Match
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches [post]
-func createMatch(c *gin.Context) {
-	var newMatch struct {
-		HomeTeam  string `json:"homeTeam" binding:"required"`
-		AwayTeam  string `json:"awayTeam" binding:"required"`
-		MatchDate string `json:"matchDate" binding:"required"`
-	}
+func (a *app) createMatch(c *gin.Context) {
+	var newMatch matchRequest
 
-	if err := c.BindJSON(&newMatch); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "Datos inválidos", "error": err.Error()})
+	if err := c.ShouldBindJSON(&newMatch); err != nil {
+		respondBindError(c, err)
 		return
 	}
 
 	parsedDate, err := time.Parse("2006-01-02", newMatch.MatchDate)
 	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "Formato de fecha inválido. Use YYYY-MM-DD"})
+		respondInvalidDate(c)
+		return
+	}
+
+	homeID, awayID, ok := a.resolveTeams(c, newMatch.matchTeams)
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-	var id int
-	err = db.QueryRow(ctx,
-		"INSERT INTO matches (home_team, away_team, match_date) VALUES ($1, $2, $3) RETURNING id",
-		newMatch.HomeTeam, newMatch.AwayTeam, parsedDate,
-	).Scan(&id)
+	ctx := c.Request.Context()
+	match, err := a.store.CreateMatch(ctx, MatchInput{
+		HomeTeamID: homeID,
+		AwayTeamID: awayID,
+		MatchDate:  parsedDate,
+	})
 
 	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondStoreError(c, err)
 		return
 	}
 
+	setMatchETag(c, match)
 	c.IndentedJSON(http.StatusCreated, gin.H{
-		"id":        id,
-		"homeTeam":  newMatch.HomeTeam,
-		"awayTeam":  newMatch.AwayTeam,
-		"matchDate": newMatch.MatchDate,
+		"id":         match.ID,
+		"homeTeamId": match.HomeTeamID,
+		"homeTeam":   match.HomeTeam,
+		"awayTeamId": match.AwayTeamID,
+		"awayTeam":   match.AwayTeam,
+		"matchDate":  newMatch.MatchDate,
 	})
 }
 
 // matchById godoc
 // @Summary Obtener un partido por ID
-// @Description Retorna un partido específico según su ID
+// @Description Retorna un partido específico según su ID. El ETag de la respuesta se envía en If-Match
+// @Description para modificarlo o eliminarlo sin pisar cambios de otros clientes.
 // @Tags matches
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del Partido"
+// @Param If-None-Match header string false "ETag de una respuesta anterior"
+// @Param If-Modified-Since header string false "Last-Modified de una respuesta anterior"
 // @Success 200 {object} Match
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Header 200 {string} ETag "Versión del partido"
+// @Header 200 {string} Last-Modified "Fecha de la última modificación (updatedAt)"
+// @Success 304 "El partido no cambió"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches/{id} [get]
-func matchById(c *gin.Context) {
-	id := c.Param("id")
-	matchID, err := strconv.Atoi(id)
-	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "ID debe ser un número"})
+func (a *app) matchById(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
 		return
 	}
 
-	var match Match
-	ctx := context.Background()
-	err = db.QueryRow(ctx, `
-        SELECT id, home_team, away_team, match_date, 
-            yellow_cards, red_cards, extra_time 
-        FROM matches WHERE id = $1`,
-		matchID,
-	).Scan(&match.ID, &match.HomeTeam, &match.AwayTeam, &match.MatchDate,
-		&match.YellowCards, &match.RedCards, &match.ExtraTime)
+	ctx := c.Request.Context()
+	match, err := a.store.GetMatch(ctx, matchID)
 
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Partido no encontrado"})
-		} else {
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
+		respondStoreError(c, err)
 		return
 	}
 
+	c.Header("Accept-Patch", acceptPatch)
+	if notModified(c, matchETag(match), match.UpdatedAt, a.cache.forMatch(match, time.Now())) {
+		return
+	}
 	c.IndentedJSON(http.StatusOK, match)
 }
 
@@ -175,314 +247,289 @@ func matchById(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del Partido"
+// @Param If-Match header string false "ETag leído del partido"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 412 {object} Problem
+// @Failure 428 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches/{id} [delete]
-func deleteMatch(c *gin.Context) {
-	id := c.Param("id")
-	matchID, err := strconv.Atoi(id)
-	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "ID debe ser un número"})
+func (a *app) deleteMatch(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-	result, err := db.Exec(ctx, "DELETE FROM matches WHERE id = $1", matchID)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, ok := a.expectedVersion(c, matchID)
+	if !ok {
 		return
 	}
 
-	if result.RowsAffected() == 0 {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Partido no encontrado"})
+	ctx := c.Request.Context()
+	err := a.store.DeleteMatch(ctx, matchID, version)
+	if err != nil {
+		respondStoreError(c, err)
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "Partido eliminado correctamente"})
+	c.IndentedJSON(http.StatusOK, gin.H{"message": tr(c, "match.deleted")})
 }
 
 // updateMatch godoc
 // @Summary Actualizar un partido
-// @Description Actualiza todos los campos de un partido existente
+// @Description Actualiza todos los campos de un partido existente. Los equipos se indican como en POST /api/matches.
 // @Tags matches
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del Partido"
-// @Param match body object{homeTeam=string,awayTeam=string,matchDate=string} true "Datos actualizados del partido"
+// @Param match body matchRequest true "Datos actualizados del partido"
+// @Param If-Match header string false "ETag leído del partido"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Header 200 {string} ETag "Nueva versión del partido"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 412 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 428 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches/{id} [put]
-func updateMatch(c *gin.Context) {
-	id := c.Param("id")
-	matchID, err := strconv.Atoi(id)
-	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "ID debe ser un número"})
+func (a *app) updateMatch(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
 		return
 	}
 
-	var updatedData struct {
-		HomeTeam  string `json:"homeTeam" binding:"required"`
-		AwayTeam  string `json:"awayTeam" binding:"required"`
-		MatchDate string `json:"matchDate" binding:"required"`
-	}
+	var updatedData matchRequest
 
 	if err := c.ShouldBindJSON(&updatedData); err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{
-			"message": "Debes enviar homeTeam, awayTeam y matchDate",
-			"error":   err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
 	parsedDate, err := time.Parse("2006-01-02", updatedData.MatchDate)
 	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "Formato de fecha inválido. Use YYYY-MM-DD"})
+		respondInvalidDate(c)
 		return
 	}
 
-	ctx := context.Background()
-	result, err := db.Exec(ctx,
-		"UPDATE matches SET home_team = $1, away_team = $2, match_date = $3 WHERE id = $4",
-		updatedData.HomeTeam, updatedData.AwayTeam, parsedDate, matchID,
-	)
+	homeID, awayID, ok := a.resolveTeams(c, updatedData.matchTeams)
+	if !ok {
+		return
+	}
 
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, ok := a.expectedVersion(c, matchID)
+	if !ok {
 		return
 	}
 
-	if result.RowsAffected() == 0 {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Partido no encontrado"})
+	ctx := c.Request.Context()
+	match, err := a.store.UpdateMatch(ctx, matchID, MatchInput{
+		HomeTeamID: homeID,
+		AwayTeamID: awayID,
+		MatchDate:  parsedDate,
+		Version:    version,
+	})
+
+	if err != nil {
+		respondStoreError(c, err)
 		return
 	}
 
+	setMatchETag(c, match)
 	c.IndentedJSON(http.StatusOK, gin.H{
-		"message": "Partido actualizado correctamente",
+		"message": tr(c, "match.updated"),
 	})
 }
 
 // @Summary Registrar un gol
-// @Description Incrementa el contador general de goles
+// @Description Suma un gol al marcador del equipo indicado en side y al total de goles, y lo agrega a la cronología
+// @Description con el minuto y el jugador si se indican.
 // @Tags matches
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del Partido"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param goal body goalRequest true "Equipo que anotó"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 200 {object} object{message=string,homeScore=int,awayScore=int,result=string,event=MatchEvent}
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches/{id}/goals [patch]
-func registerGoal(c *gin.Context) {
-	id := c.Param("id")
-	matchID, err := strconv.Atoi(id)
-	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "ID debe ser un número"})
+func (a *app) registerGoal(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-	result, err := db.Exec(ctx,
-		"UPDATE matches SET goals = goals + 1 WHERE id = $1",
-		matchID,
-	)
-
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var goal goalRequest
+	if err := c.ShouldBindJSON(&goal); err != nil {
+		respondBindError(c, err)
 		return
 	}
 
-	if result.RowsAffected() == 0 {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Partido no encontrado"})
+	event, match, ok := a.addEvent(c, goal.input(matchID, eventGoal, goal.Side))
+	if !ok {
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "Gol registrado correctamente"})
+	c.IndentedJSON(http.StatusOK, gin.H{
+		"message":   tr(c, "match.goal"),
+		"homeScore": match.HomeScore,
+		"awayScore": match.AwayScore,
+		"result":    match.result(),
+		"event":     event,
+	})
 }
 
 // registerYellowCard godoc
 // @Summary Registrar tarjeta amarilla
-// @Description Incrementa el contador de tarjetas amarillas del partido
+// @Description Incrementa el contador de tarjetas amarillas del partido y agrega la tarjeta a la cronología.
+// @Description El cuerpo es opcional.
 // @Tags matches
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del Partido"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param card body cardRequest false "Equipo, minuto y jugador"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 200 {object} object{message=string,event=MatchEvent}
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches/{id}/yellowcards [patch]
-func registerYellowCard(c *gin.Context) {
-	id := c.Param("id")
-	matchID, err := strconv.Atoi(id)
-	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "ID debe ser un número"})
+func (a *app) registerYellowCard(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-	result, err := db.Exec(ctx,
-		"UPDATE matches SET yellow_cards = yellow_cards + 1 WHERE id = $1",
-		matchID,
-	)
-
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var card cardRequest
+	if !bindOptionalJSON(c, &card) {
 		return
 	}
-
-	if result.RowsAffected() == 0 {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Partido no encontrado"})
+	event, _, ok := a.addEvent(c, card.input(matchID, eventYellowCard, card.Team))
+	if !ok {
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "Tarjeta amarilla registrada"})
+	c.IndentedJSON(http.StatusOK, gin.H{"message": tr(c, "match.yellow_card"), "event": event})
 }
 
 // registerRedCard godoc
 // @Summary Registrar tarjeta roja
-// @Description Incrementa el contador de tarjetas rojas del partido
+// @Description Incrementa el contador de tarjetas rojas del partido y agrega la tarjeta a la cronología.
+// @Description El cuerpo es opcional.
 // @Tags matches
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del Partido"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param card body cardRequest false "Equipo, minuto y jugador"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 200 {object} object{message=string,event=MatchEvent}
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches/{id}/redcards [patch]
-func registerRedCard(c *gin.Context) {
-	id := c.Param("id")
-	matchID, err := strconv.Atoi(id)
-	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "ID debe ser un número"})
+func (a *app) registerRedCard(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-	result, err := db.Exec(ctx,
-		"UPDATE matches SET red_cards = red_cards + 1 WHERE id = $1",
-		matchID,
-	)
-
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var card cardRequest
+	if !bindOptionalJSON(c, &card) {
 		return
 	}
-
-	if result.RowsAffected() == 0 {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Partido no encontrado"})
+	event, _, ok := a.addEvent(c, card.input(matchID, eventRedCard, card.Team))
+	if !ok {
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "Tarjeta roja registrada"})
+	c.IndentedJSON(http.StatusOK, gin.H{"message": tr(c, "match.red_card"), "event": event})
 }
 
 // setExtraTime godoc
 // @Summary Incrementar tiempo extra
-// @Description Incrementa en 1 minuto el tiempo extra del partido (hasta máximo 30 minutos)
+// @Description Incrementa en 1 minuto el tiempo extra del partido (hasta máximo 30 minutos) y agrega el cambio a la cronología.
+// @Description En el máximo no se agrega el evento y la respuesta lo indica. El cuerpo es opcional.
 // @Tags matches
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del Partido"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param details body eventDetails false "Minuto del cambio"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 200 {object} object{message=string,extraTime=int,event=MatchEvent}
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
 // @Router /matches/{id}/extratime [patch]
-func setExtraTime(c *gin.Context) {
-	id := c.Param("id")
-	matchID, err := strconv.Atoi(id)
-	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "ID debe ser un número"})
+func (a *app) setExtraTime(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-
-	
-	var currentExtraTime int
-	err = db.QueryRow(ctx, "SELECT extra_time FROM matches WHERE id = $1", matchID).Scan(&currentExtraTime)
-
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Partido no encontrado"})
-		} else {
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
+	var details eventDetails
+	if !bindOptionalJSON(c, &details) {
 		return
 	}
-
-	
-	newExtraTime := currentExtraTime + 1
-	if newExtraTime > 30 {
-		newExtraTime = 30
+	in := details.input(matchID, eventExtraTime, "")
+	if fields := eventFieldErrors(c, in); len(fields) > 0 {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"), fields...)
+		return
 	}
 
-	
-	result, err := db.Exec(ctx,
-		"UPDATE matches SET extra_time = $1 WHERE id = $2",
-		newExtraTime, matchID,
-	)
-
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	event, match, err := a.store.AddMatchEvent(c.Request.Context(), in)
+	if errors.Is(err, ErrExtraTimeLimit) {
+		c.IndentedJSON(http.StatusOK, gin.H{
+			"message":   tr(c, "match.extra_time_max", maxExtraTime),
+			"extraTime": maxExtraTime,
+		})
 		return
 	}
-
-	if result.RowsAffected() == 0 {
-		c.IndentedJSON(http.StatusNotFound, gin.H{"message": "Partido no encontrado"})
+	if err != nil {
+		respondStoreError(c, err)
 		return
 	}
+	countEvent(a.metrics.extraTime, match)
+	newExtraTime := match.ExtraTime
 
-	
-	message := fmt.Sprintf("Tiempo extra incrementado a %d minutos", newExtraTime)
-	if newExtraTime >= 30 {
-		message = "Tiempo extra alcanzó el máximo de 30 minutos"
+	message := tr(c, "match.extra_time", newExtraTime)
+	if newExtraTime >= maxExtraTime {
+		message = tr(c, "match.extra_time_max", maxExtraTime)
 	}
 
 	c.IndentedJSON(http.StatusOK, gin.H{
 		"message":   message,
 		"extraTime": newExtraTime,
+		"event":     event,
 	})
 }
 
-func initDB() error {
-	connStr := fmt.Sprintf(
-		"postgres://%s:%s@%s/%s",
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_NAME"),
-	)
-
-	var err error
-	var conn *pgx.Conn
-
-	for i := 0; i < 5; i++ {
-		ctx := context.Background()
-		conn, err = pgx.Connect(ctx, connStr)
-		if err == nil {
-			break
-		}
-		time.Sleep(time.Duration(i*i) * time.Second)
-	}
-
-	if err != nil {
-		return fmt.Errorf("no se pudo conectar a la base de datos después de 5 intentos: %v", err)
-	}
-
-	if err := conn.Ping(context.Background()); err != nil {
-		return fmt.Errorf("no se pudo hacer ping a la base de datos: %v", err)
-	}
-
-	db = conn
-	return nil
-}
-
 // @title LaLigaTracker API
 // @version 1.0
 // @description API para gestión de partidos de fútbol
@@ -493,45 +540,159 @@ func initDB() error {
 // @host localhost:8080
 // @BasePath /api
 func main() {
-	if err := initDB(); err != nil {
-		fmt.Printf("Error inicializando la base de datos: %v\n", err)
+	flags := registerConfigFlags(flag.CommandLine)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Uso: %s [opciones] [config | migrate up|down [n]|status|create <nombre>]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "", "config", "migrate":
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := loadConfig(flags, os.LookupEnv)
+	if err != nil {
+		fmt.Printf("Configuración inválida:\n%v\n", err)
 		os.Exit(1)
 	}
-	defer db.Close(context.Background())
 
-	router := gin.Default()
+	switch flag.Arg(0) {
+	case "config":
+		if err := printConfig(os.Stdout, cfg); err != nil {
+			fmt.Printf("Error imprimiendo la configuración: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	case "migrate":
+		if err := runMigrateCommand(cfg, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Printf("Error en migrate: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	router.Use(func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type")
+	slog.SetDefault(newLogger(os.Stdout, cfg.Log))
+	for lang, keys := range missingTranslations() {
+		slog.Warn("faltan traducciones, se usará el español", "lang", lang, "keys", keys)
+	}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+	store, pool, err := openStore(cfg)
+	if err != nil {
+		fmt.Printf("Error inicializando el almacenamiento: %v\n", err)
+		os.Exit(1)
+	}
+
+	m := newMetrics(pool)
+	instrumented := newInstrumentedStore(store, m)
+	router := newRouter(&app{store: instrumented, pool: pool, timeouts: cfg.Timeouts, cors: cfg.CORS, metrics: m, pagination: cfg.Pagination, concurrency: cfg.Concurrency, cache: cfg.Cache, idempotency: cfg.Idempotency, versions: cfg.Versions}, cfg.Metrics)
+	purge := func(ctx context.Context) { purgeIdempotencyKeys(ctx, instrumented, cfg.Idempotency) }
+	if err := runServer(cfg.Server, router, store, purge); err != nil {
+		slog.Error("error en el servidor", "error", err)
+		os.Exit(1)
+	}
+}
+
+// openStore crea el almacenamiento indicado por cfg.Store. El pool solo
+// se retorna para postgres y es nil con el almacenamiento en memoria.
+// Con cfg.AutoMigrate se aplican las migraciones pendientes antes de retornar.
+func openStore(cfg Config) (MatchStore, *pgxpool.Pool, error) {
+	switch cfg.Store {
+	case "postgres":
+		pool, err := initDB(cfg.Database)
+		if err != nil {
+			return nil, nil, err
 		}
-		c.Next()
-	})
+		if cfg.AutoMigrate {
+			if err := applyMigrations(pool); err != nil {
+				pool.Close()
+				return nil, nil, err
+			}
+		}
+		return newPostgresStore(pool), pool, nil
+	case "memory":
+		return newMemoryStore(), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("almacenamiento desconocido %q, use postgres o memory", cfg.Store)
+	}
+}
 
-	// Configuración de Swagger
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+func newRouter(a *app, ms metricsSettings) *gin.Engine {
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(routeNotFound)
+	router.NoMethod(methodNotAllowed)
+	useJSONFieldNames()
+
+	cors := newCORSPolicy(a.cors)
+	router.Use(requestContext(slog.Default()), localize(), accessLog(), a.metrics.middleware(), recoverPanic())
+	router.Use(cors.middleware())
+	router.Use(requestTimeout(a.timeouts))
+
+	// Configuración de Swagger: un documento por versión de la API
+	router.GET("/swagger/*any", swaggerDocs())
+
+	if ms.Enabled {
+		router.GET(ms.Path, a.metrics.handler())
+	}
 
 	api := router.Group("/api")
 	{
-		api.GET("/matches", getMatch)
-		api.POST("/matches", createMatch)
-		api.GET("/matches/:id", matchById)
-		api.DELETE("/matches/:id", deleteMatch)
-		api.PUT("/matches/:id", updateMatch)
-
-		api.PATCH("/matches/:id/goals", registerGoal)
-		api.PATCH("/matches/:id/yellowcards", registerYellowCard)
-		api.PATCH("/matches/:id/redcards", registerRedCard)
-		api.PATCH("/matches/:id/extratime", setExtraTime)
+		v1 := api.Group("/matches", a.versions.deprecatedV1())
+		v1.GET("", a.getMatch)
+		v1.GET("/search", a.searchMatches)
+		v1.POST("", a.idempotent(defaultBodyLimit), a.createMatch)
+		v1.POST("/batch", a.idempotent(batchBodyLimit), a.createMatchBatch)
+		v1.GET("/:id", a.matchById)
+		v1.DELETE("/:id", a.deleteMatch)
+		v1.PUT("/:id", a.updateMatch)
+		v1.PATCH("/:id", a.patchMatch)
+
+		v1.PATCH("/:id/goals", a.idempotent(defaultBodyLimit), a.registerGoal)
+		v1.PATCH("/:id/yellowcards", a.idempotent(defaultBodyLimit), a.registerYellowCard)
+		v1.PATCH("/:id/redcards", a.idempotent(defaultBodyLimit), a.registerRedCard)
+		v1.PATCH("/:id/extratime", a.idempotent(defaultBodyLimit), a.setExtraTime)
+
+		v1.GET("/:id/events", a.listMatchEvents)
+		v1.POST("/:id/events", a.idempotent(defaultBodyLimit), a.createMatchEvent)
+		v1.POST("/:id/events/:eventId/void", a.idempotent(defaultBodyLimit), a.voidMatchEvent)
+		v1.POST("/:id/goals/reversal", a.idempotent(defaultBodyLimit), a.reverseGoal)
+		v1.POST("/:id/yellowcards/reversal", a.idempotent(defaultBodyLimit), a.reverseYellowCard)
+		v1.POST("/:id/redcards/reversal", a.idempotent(defaultBodyLimit), a.reverseRedCard)
+
+		teams := api.Group("/teams")
+		teams.GET("", a.listTeams)
+		teams.POST("", a.idempotent(defaultBodyLimit), a.createTeam)
+		teams.GET("/:id", a.getTeam)
+		teams.PUT("/:id", a.updateTeam)
+		teams.DELETE("/:id", a.deleteTeam)
+		teams.GET("/:id/players", a.listPlayers)
+		teams.POST("/:id/players", a.idempotent(defaultBodyLimit), a.createPlayer)
+		teams.GET("/:id/players/:playerId", a.getPlayer)
+		teams.PUT("/:id/players/:playerId", a.updatePlayer)
+		teams.DELETE("/:id/players/:playerId", a.deletePlayer)
+
+		api.GET("/admin/pool", a.poolStats)
+
+		api.GET("/health", a.liveness)
+		api.GET("/health/ready", a.readiness)
+		api.GET("/version", a.version)
+	}
+
+	v2 := router.Group("/api/v2")
+	{
+		v2.GET("/matches", a.listMatchesV2)
+		v2.POST("/matches", a.idempotent(defaultBodyLimit), a.createMatchV2)
+		v2.GET("/matches/:id", a.getMatchV2)
+		v2.PUT("/matches/:id", a.replaceMatchV2)
+		v2.DELETE("/matches/:id", a.deleteMatchV2)
 	}
 
-	router.Run("0.0.0.0:8080")
+	cors.learnRoutes(router.Routes())
+	return router
 }
 
 // docker-compose down -v
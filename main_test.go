@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+// testServer es el router completo sobre un almacenamiento en memoria
+type testServer struct {
+	t      *testing.T
+	router *gin.Engine
+	store  MatchStore
+}
+
+// newTestServer arma el router con la configuración por defecto, como main.
+// configure puede ajustar la configuración antes de crear el router.
+func newTestServer(t *testing.T, configure ...func(*Config)) *testServer {
+	t.Helper()
+	env := func(name string) (string, bool) {
+		if name == "STORE" {
+			return "memory", true
+		}
+		return "", false
+	}
+	cfg, err := loadConfig(registerConfigFlags(flag.NewFlagSet("test", flag.ContinueOnError)), env)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+	store := newMemoryStore()
+	m := newMetrics(nil)
+	a := &app{store: newInstrumentedStore(store, m), timeouts: cfg.Timeouts, cors: cfg.CORS, metrics: m, pagination: cfg.Pagination, concurrency: cfg.Concurrency, cache: cfg.Cache, idempotency: cfg.Idempotency, versions: cfg.Versions}
+	return &testServer{t: t, router: newRouter(a, cfg.Metrics), store: store}
+}
+
+// do envía una petición al router. body puede ser nil, un string que se
+// envía tal cual o un valor que se codifica como JSON; header son pares de
+// nombre y valor.
+func (s *testServer) do(method, target string, body any, header ...string) *httptest.ResponseRecorder {
+	s.t.Helper()
+	var r io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		r = strings.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			s.t.Fatalf("json.Marshal: %v", err)
+		}
+		r = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, target, r)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// team crea un equipo directamente en el almacenamiento
+func (s *testServer) team(name string) Team {
+	s.t.Helper()
+	t, err := s.store.CreateTeam(context.Background(), TeamInput{Name: name})
+	if err != nil {
+		s.t.Fatalf("CreateTeam(%q): %v", name, err)
+	}
+	return t
+}
+
+// match crea un partido entre home y away directamente en el almacenamiento
+func (s *testServer) match(home, away Team, date string) Match {
+	s.t.Helper()
+	d, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	m, err := s.store.CreateMatch(context.Background(), MatchInput{HomeTeamID: home.ID, AwayTeamID: away.ID, MatchDate: d})
+	if err != nil {
+		s.t.Fatalf("CreateMatch: %v", err)
+	}
+	return m
+}
+
+// decode lee el cuerpo JSON de rec
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("cuerpo inválido %q: %v", rec.Body.String(), err)
+	}
+	return v
+}
+
+// expectStatus falla si rec no tiene el estado esperado
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("estado %d, se esperaba %d: %s", rec.Code, want, rec.Body.String())
+	}
+}
+
+// expectProblem falla si rec no es un problem+json con el estado y el código
+// esperados y lo retorna
+func expectProblem(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) Problem {
+	t.Helper()
+	expectStatus(t, rec, status)
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, problemContentType) {
+		t.Fatalf("Content-Type %q, se esperaba %s", ct, problemContentType)
+	}
+	p := decode[Problem](t, rec)
+	if p.Code != code || p.Status != status {
+		t.Fatalf("problema %s (%d), se esperaba %s (%d): %+v", p.Code, p.Status, code, status, p)
+	}
+	return p
+}
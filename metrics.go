@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace es el prefijo de todas las métricas del servicio
+const metricsNamespace = "laliga"
+
+// metricsSettings define si se expone el endpoint de Prometheus y en qué ruta
+type metricsSettings struct {
+	Enabled bool
+	Path    string
+}
+
+// metrics agrupa las métricas del servicio en un registro propio para no
+// depender del registro global de Prometheus
+type metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpInFlight prometheus.Gauge
+
+	dbDuration *prometheus.HistogramVec
+	dbErrors   *prometheus.CounterVec
+
+	goals       *prometheus.CounterVec
+	yellowCards *prometheus.CounterVec
+	redCards    *prometheus.CounterVec
+	extraTime   *prometheus.CounterVec
+}
+
+// newMetrics crea y registra las métricas. Con pool nil (almacenamiento en
+// memoria) no se publican las estadísticas del pool.
+func newMetrics(pool *pgxpool.Pool) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "Peticiones HTTP atendidas por método, ruta y estado.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latencia de las peticiones HTTP por método y ruta.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_in_flight",
+			Help:      "Peticiones HTTP en curso.",
+		}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duración de las operaciones del almacenamiento.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"operation"}),
+		dbErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "db_query_errors_total",
+			Help:      "Operaciones del almacenamiento que fallaron, sin contar partidos inexistentes.",
+		}, []string{"operation"}),
+		goals:       domainCounter("goals_total", "Goles registrados."),
+		yellowCards: domainCounter("yellow_cards_total", "Tarjetas amarillas registradas."),
+		redCards:    domainCounter("red_cards_total", "Tarjetas rojas registradas."),
+		extraTime:   domainCounter("extra_time_increments_total", "Minutos de tiempo extra agregados."),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.httpInFlight,
+		m.dbDuration, m.dbErrors,
+		m.goals, m.yellowCards, m.redCards, m.extraTime,
+	)
+	if pool != nil {
+		m.registry.MustRegister(newPoolCollector(pool))
+	}
+	return m
+}
+
+// domainCounter crea un contador de eventos de partido etiquetado por el
+// estado del partido (scheduled, live o finished)
+func domainCounter(name, help string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      name,
+		Help:      help,
+	}, []string{"match_status"})
+}
+
+// countEvent suma uno al contador indicado con el estado actual del partido
+func countEvent(counter *prometheus.CounterVec, m Match) {
+	counter.WithLabelValues(matchStatus(m.MatchDate, time.Now())).Inc()
+}
+
+// handler expone el registro en el formato de texto de Prometheus
+func (m *metrics) handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// middleware mide cada petición. La ruta es la plantilla de gin
+// (/api/matches/:id) para no crear una serie por cada id.
+func (m *metrics) middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		m.httpInFlight.Inc()
+		defer m.httpInFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		m.httpDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// expectedStoreErrors son los errores del almacenamiento que responden a la
+// petición (un partido inexistente, una versión desactualizada, ...) y no a
+// una falla de la base de datos
+var expectedStoreErrors = []error{
+	ErrMatchNotFound, ErrVersionMismatch, ErrExtraTimeLimit,
+	ErrEventNotFound, ErrEventAlreadyVoided, ErrCounterBelowZero,
+	ErrTeamNotFound, ErrUnknownTeam, ErrTeamConflict, ErrTeamInUse,
+	ErrPlayerNotFound, ErrShirtNumberTaken, ErrPlayerInUse, ErrUnknownPlayer, ErrPlayerNotInMatch,
+}
+
+// observeQuery registra la duración de una operación del almacenamiento y,
+// si falló por algo distinto a expectedStoreErrors, cuenta el error
+func (m *metrics) observeQuery(operation string, start time.Time, err error) {
+	m.dbDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err == nil {
+		return
+	}
+	for _, expected := range expectedStoreErrors {
+		if errors.Is(err, expected) {
+			return
+		}
+	}
+	m.dbErrors.WithLabelValues(operation).Inc()
+}
+
+// poolCollector publica las estadísticas de pgxpool en cada scrape
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	totalConns     *prometheus.Desc
+	idleConns      *prometheus.Desc
+	acquiredConns  *prometheus.Desc
+	maxConns       *prometheus.Desc
+	acquireCount   *prometheus.Desc
+	acquireSeconds *prometheus.Desc
+	emptyAcquire   *prometheus.Desc
+	canceled       *prometheus.Desc
+}
+
+func newPoolCollector(pool *pgxpool.Pool) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "db_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		pool:           pool,
+		totalConns:     desc("total_conns", "Conexiones abiertas en el pool."),
+		idleConns:      desc("idle_conns", "Conexiones inactivas en el pool."),
+		acquiredConns:  desc("acquired_conns", "Conexiones en uso."),
+		maxConns:       desc("max_conns", "Tamaño máximo del pool."),
+		acquireCount:   desc("acquire_total", "Conexiones obtenidas del pool."),
+		acquireSeconds: desc("acquire_duration_seconds_total", "Tiempo total esperando conexiones del pool."),
+		emptyAcquire:   desc("empty_acquire_total", "Veces que se esperó porque el pool no tenía conexiones libres."),
+		canceled:       desc("canceled_acquire_total", "Esperas de conexión canceladas por el contexto."),
+	}
+}
+
+func (p *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(p, ch)
+}
+
+func (p *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := p.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(p.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(p.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(p.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(p.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(p.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(p.acquireSeconds, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(p.emptyAcquire, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(p.canceled, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationsDir es el directorio de las migraciones dentro del repositorio
+const migrationsDir = "migrations"
+
+// migrationLockID identifica el advisory lock que evita que dos instancias
+// migren la misma base de datos a la vez
+const migrationLockID = 6_060_2025
+
+// migrationFileRe reconoce nombres como 0001_create_matches.up.sql
+var migrationFileRe = regexp.MustCompile(`^(\d{4,})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var migrationNameRe = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// migration es una versión del esquema con su script de subida y de bajada
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// migrationState indica si una migración está aplicada en la base de datos
+type migrationState struct {
+	migration
+	AppliedAt *time.Time
+}
+
+// expectedSchemaVersion es la versión del esquema que espera este binario:
+// la última migración incrustada
+var expectedSchemaVersion = latestMigrationVersion()
+
+// loadMigrations lee las migraciones del directorio dir de fsys ordenadas por versión
+func loadMigrations(fsys fs.FS, dir string) ([]migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*migration{}
+	for _, entry := range entries {
+		parts := migrationFileRe.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			return nil, fmt.Errorf("nombre de migración inválido %q, use NNNN_nombre.up.sql o NNNN_nombre.down.sql", entry.Name())
+		}
+		version, _ := strconv.Atoi(parts[1])
+		body, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Name: parts[2]}
+			byVersion[version] = m
+		} else if m.Name != parts[2] {
+			return nil, fmt.Errorf("la versión %d tiene dos nombres: %q y %q", version, m.Name, parts[2])
+		}
+		if parts[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("la migración %04d_%s debe tener script up y down", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func latestMigrationVersion() int {
+	migrations, err := loadMigrations(migrationFiles, migrationsDir)
+	if err != nil {
+		panic(fmt.Sprintf("migraciones incrustadas inválidas: %v", err))
+	}
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// migrator aplica las migraciones incrustadas sobre una base de datos
+type migrator struct {
+	pool       *pgxpool.Pool
+	migrations []migration
+}
+
+func newMigrator(pool *pgxpool.Pool) (*migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+	return &migrator{pool: pool, migrations: migrations}, nil
+}
+
+// withLock ejecuta fn en una conexión dedicada que mantiene el advisory lock
+func (m *migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("no se pudo obtener el lock de migraciones: %w", err)
+	}
+	defer conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	if _, err := conn.Exec(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version INT PRIMARY KEY,
+            applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+        )`); err != nil {
+		return fmt.Errorf("no se pudo crear schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int]time.Time, error) {
+	rows, err := conn.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// Up aplica todas las migraciones pendientes, cada una en su propia transacción
+func (m *migrator) Up(ctx context.Context) ([]migration, error) {
+	var done []migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", mig.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migración %04d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down revierte las últimas steps migraciones aplicadas
+func (m *migrator) Down(ctx context.Context, steps int) ([]migration, error) {
+	var done []migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", mig.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migración %04d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status retorna todas las migraciones conocidas indicando cuáles están aplicadas
+func (m *migrator) Status(ctx context.Context) ([]migrationState, error) {
+	var states []migrationState
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			state := migrationState{migration: mig}
+			if at, ok := applied[mig.Version]; ok {
+				state.AppliedAt = &at
+			}
+			states = append(states, state)
+		}
+		return nil
+	})
+	return states, err
+}
+
+// applyMigrations aplica las migraciones pendientes al iniciar el servidor
+func applyMigrations(pool *pgxpool.Pool) error {
+	m, err := newMigrator(pool)
+	if err != nil {
+		return err
+	}
+	done, err := m.Up(context.Background())
+	for _, mig := range done {
+		slog.Info("migración aplicada", "version", mig.Version, "name", mig.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("no se pudieron aplicar las migraciones: %w", err)
+	}
+	return nil
+}
+
+// createMigration crea el par de archivos vacíos de la siguiente versión en dir
+func createMigration(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+	if !migrationNameRe.MatchString(name) {
+		return nil, fmt.Errorf("nombre inválido %q, use solo letras, números y guiones bajos", name)
+	}
+
+	migrations, err := loadMigrations(os.DirFS(dir), ".")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	next := 1
+	if len(migrations) > 0 {
+		next = migrations[len(migrations)-1].Version + 1
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, direction := range []string{"up", "down"} {
+		file := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", next, name, direction))
+		body := fmt.Sprintf("-- %04d_%s (%s)\n", next, name, direction)
+		if err := os.WriteFile(file, []byte(body), 0o644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, file)
+	}
+	return paths, nil
+}
+
+// runMigrateCommand implementa "migrate up|down [n]|status|create <nombre>"
+func runMigrateCommand(cfg Config, args []string, out io.Writer) error {
+	fset := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := fset.String("dir", migrationsDir, "directorio donde \"create\" escribe las migraciones")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	args = fset.Args()
+	if len(args) == 0 {
+		return fmt.Errorf("uso: migrate up|down [n]|status|create <nombre>")
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return fmt.Errorf("uso: migrate create <nombre>")
+		}
+		paths, err := createMigration(*dir, args[1])
+		if err != nil {
+			return err
+		}
+		for _, file := range paths {
+			fmt.Fprintf(out, "Creado %s\n", file)
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "up", "down", "status":
+	default:
+		return fmt.Errorf("subcomando desconocido %q, use up, down, status o create", args[0])
+	}
+
+	pool, err := initDB(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	m, err := newMigrator(pool)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(ctx)
+		for _, mig := range done {
+			fmt.Fprintf(out, "Aplicada %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Fprintln(out, "El esquema ya está actualizado")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("el número de pasos debe ser un entero positivo")
+			}
+		}
+		done, err := m.Down(ctx, steps)
+		for _, mig := range done {
+			fmt.Fprintf(out, "Revertida %04d_%s\n", mig.Version, mig.Name)
+		}
+		return err
+	case "status":
+		states, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range states {
+			applied := "pendiente"
+			if st.AppliedAt != nil {
+				applied = "aplicada " + st.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(out, "%04d_%-30s %s\n", st.Version, st.Name, applied)
+		}
+	}
+	return nil
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS matches_match_date_id_idx;
@@ -0,0 +1,2 @@
+-- Acelera el listado ordenado por fecha y la paginación por cursor
+CREATE INDEX IF NOT EXISTS matches_match_date_id_idx ON matches (match_date, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS matches_away_team_trgm_idx;
+DROP INDEX IF EXISTS matches_home_team_trgm_idx;
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
+-- Las extensiones se conservan: otras bases de datos u objetos pueden usarlas
@@ -0,0 +1,15 @@
+-- Búsqueda de equipos sin distinguir acentos (unaccent) y por similitud (pg_trgm).
+-- Ambas extensiones son "trusted" desde PostgreSQL 13, basta con ser dueño de la base.
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent() no es IMMUTABLE porque depende del diccionario por defecto;
+-- fijarlo permite usarla en índices
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+CREATE INDEX IF NOT EXISTS matches_home_team_trgm_idx
+    ON matches USING gin (immutable_unaccent(lower(home_team)) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS matches_away_team_trgm_idx
+    ON matches USING gin (immutable_unaccent(lower(away_team)) gin_trgm_ops);
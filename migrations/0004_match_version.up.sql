@@ -0,0 +1,3 @@
+-- Versión de cada partido para el control de concurrencia optimista (ETag / If-Match).
+-- Cada escritura la incrementa en la misma sentencia UPDATE.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
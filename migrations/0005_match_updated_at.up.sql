@@ -0,0 +1,3 @@
+-- Fecha de la última modificación de cada partido, para Last-Modified y los
+-- GET condicionales. Cada escritura la actualiza junto con version.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now();
@@ -0,0 +1,12 @@
+-- Respuestas guardadas por Idempotency-Key para reenviarlas si el cliente
+-- repite la petición. status es 0 mientras la petición original está en curso.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key         text PRIMARY KEY,
+    fingerprint text NOT NULL,
+    status      integer NOT NULL DEFAULT 0,
+    headers     jsonb NOT NULL DEFAULT '{}',
+    body        bytea,
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_created_at_idx ON idempotency_keys (created_at);
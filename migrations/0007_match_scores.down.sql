@@ -0,0 +1,4 @@
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS matches_scores_check;
+ALTER TABLE matches ALTER COLUMN goals DROP NOT NULL;
+ALTER TABLE matches DROP COLUMN IF EXISTS away_score;
+ALTER TABLE matches DROP COLUMN IF EXISTS home_score;
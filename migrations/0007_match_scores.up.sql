@@ -0,0 +1,9 @@
+-- Marcador de cada equipo. goals sigue siendo el total: los goles registrados
+-- antes de esta migración no se pueden asignar a un equipo y quedan como la
+-- diferencia goals - home_score - away_score, por lo que ningún total cambia.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS home_score integer NOT NULL DEFAULT 0;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS away_score integer NOT NULL DEFAULT 0;
+UPDATE matches SET goals = 0 WHERE goals IS NULL;
+ALTER TABLE matches ALTER COLUMN goals SET NOT NULL;
+ALTER TABLE matches ADD CONSTRAINT matches_scores_check
+    CHECK (home_score >= 0 AND away_score >= 0 AND home_score + away_score <= goals);
@@ -0,0 +1,15 @@
+-- Cronología de cada partido. Cada evento que cambia un contador de matches
+-- se inserta en la misma transacción que lo actualiza. Los contadores
+-- anteriores a esta migración no tienen eventos.
+CREATE TABLE IF NOT EXISTS match_events (
+    id          bigserial PRIMARY KEY,
+    match_id    integer NOT NULL REFERENCES matches (id) ON DELETE CASCADE,
+    type        text NOT NULL CHECK (type IN ('goal', 'yellow_card', 'red_card', 'substitution', 'extra_time')),
+    minute      smallint CHECK (minute BETWEEN 0 AND 120),
+    added_time  smallint NOT NULL DEFAULT 0 CHECK (added_time BETWEEN 0 AND 30),
+    team        text CHECK (team IN ('home', 'away')),
+    player      text,
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS match_events_match_id_idx ON match_events (match_id, id);
@@ -0,0 +1,3 @@
+ALTER TABLE match_events DROP CONSTRAINT IF EXISTS match_events_void_check;
+ALTER TABLE match_events DROP COLUMN IF EXISTS void_reason;
+ALTER TABLE match_events DROP COLUMN IF EXISTS voided_at;
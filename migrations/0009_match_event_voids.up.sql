@@ -0,0 +1,6 @@
+-- Los eventos anulados (un gol anulado por el VAR, una tarjeta revocada)
+-- siguen en la cronología con la fecha y el motivo de la anulación.
+ALTER TABLE match_events ADD COLUMN IF NOT EXISTS voided_at timestamptz;
+ALTER TABLE match_events ADD COLUMN IF NOT EXISTS void_reason text;
+ALTER TABLE match_events ADD CONSTRAINT match_events_void_check
+    CHECK ((voided_at IS NULL) = (void_reason IS NULL));
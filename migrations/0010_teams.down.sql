@@ -0,0 +1,5 @@
+-- home_team y away_team conservan el nombre del equipo; los nombres
+-- originales que la migración unificó no se recuperan
+ALTER TABLE matches DROP COLUMN IF EXISTS away_team_id;
+ALTER TABLE matches DROP COLUMN IF EXISTS home_team_id;
+DROP TABLE IF EXISTS teams;
@@ -0,0 +1,80 @@
+-- Equipos. Hasta esta migración cada partido guardaba sus equipos como texto
+-- libre, por lo que "Barcelona", "FC Barcelona" y "Barça" eran equipos
+-- distintos. home_team y away_team se conservan como copia del nombre del
+-- equipo para el filtrado y la búsqueda; al renombrar un equipo se actualizan.
+-- Que el local y el visitante sean distintos lo valida la API, para no
+-- rechazar los cambios en partidos existentes con el mismo equipo en ambos lados.
+CREATE TABLE IF NOT EXISTS teams (
+    id          serial PRIMARY KEY,
+    name        varchar(255) NOT NULL,
+    short_name  varchar(50),
+    code        char(3) CHECK (code ~ '^[A-Z]{3}$'),
+    founded     smallint CHECK (founded BETWEEN 1850 AND 2100),
+    colors      text[] NOT NULL DEFAULT '{}',
+    crest_url   text,
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS teams_name_key ON teams (immutable_unaccent(lower(name)));
+CREATE UNIQUE INDEX IF NOT EXISTS teams_code_key ON teams (code);
+
+-- Cada nombre distinto de los partidos, normalizado como normalizeTeam y
+-- agrupado por key: los alias conocidos (los de teamAliases en search.go)
+-- comparten la key del nombre al que apuntan
+CREATE TEMPORARY TABLE team_names ON COMMIT DROP AS
+SELECT n.name, n.normalized, n.uses, COALESCE(a.canonical, n.normalized) AS key, NULL::integer AS team_id
+FROM (
+    SELECT t.name, immutable_unaccent(lower(t.name)) AS normalized, count(*) AS uses
+    FROM matches
+    CROSS JOIN LATERAL (VALUES (home_team), (away_team)) AS v(name)
+    CROSS JOIN LATERAL (SELECT btrim(regexp_replace(v.name, '\s+', ' ', 'g')) AS name) AS t
+    GROUP BY t.name
+) AS n
+LEFT JOIN (VALUES
+    ('barca', 'barcelona'),
+    ('fc barcelona', 'barcelona'),
+    ('atleti', 'atletico'),
+    ('atletico de madrid', 'atletico'),
+    ('atletico madrid', 'atletico'),
+    ('real madrid cf', 'real madrid'),
+    ('la real', 'real sociedad'),
+    ('athletic', 'athletic club'),
+    ('athletic bilbao', 'athletic club'),
+    ('bilbao', 'athletic club'),
+    ('betis', 'real betis'),
+    ('celta de vigo', 'celta'),
+    ('rayo', 'rayo vallecano'),
+    ('alaves', 'deportivo alaves')
+) AS a(alias, canonical) ON a.alias = n.normalized;
+
+-- Un equipo por key con la variante más usada; a igualdad, la que coincide
+-- con el nombre al que apuntan los alias
+INSERT INTO teams (name)
+SELECT DISTINCT ON (key) name
+FROM team_names
+ORDER BY key, uses DESC, normalized = key DESC, name
+ON CONFLICT DO NOTHING;
+
+UPDATE team_names n SET team_id = t.id
+FROM team_names k
+JOIN teams t ON immutable_unaccent(lower(t.name)) = k.normalized
+WHERE k.key = n.key;
+
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS home_team_id integer REFERENCES teams (id);
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS away_team_id integer REFERENCES teams (id);
+
+UPDATE matches m SET home_team_id = t.id, home_team = t.name
+FROM team_names n
+JOIN teams t ON t.id = n.team_id
+WHERE n.name = btrim(regexp_replace(m.home_team, '\s+', ' ', 'g'));
+
+UPDATE matches m SET away_team_id = t.id, away_team = t.name
+FROM team_names n
+JOIN teams t ON t.id = n.team_id
+WHERE n.name = btrim(regexp_replace(m.away_team, '\s+', ' ', 'g'));
+
+ALTER TABLE matches ALTER COLUMN home_team_id SET NOT NULL;
+ALTER TABLE matches ALTER COLUMN away_team_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS matches_home_team_id_idx ON matches (home_team_id);
+CREATE INDEX IF NOT EXISTS matches_away_team_id_idx ON matches (away_team_id);
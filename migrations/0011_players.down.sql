@@ -0,0 +1,2 @@
+ALTER TABLE match_events DROP COLUMN IF EXISTS player_id;
+DROP TABLE IF EXISTS players;
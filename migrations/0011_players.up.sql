@@ -0,0 +1,22 @@
+-- Plantillas. Un jugador es la inscripción de un futbolista en un equipo
+-- para una temporada ("2024-25"), por lo que el mismo futbolista en otra
+-- temporada es otra fila. El dorsal no se repite en el equipo y la temporada.
+CREATE TABLE IF NOT EXISTS players (
+    id            serial PRIMARY KEY,
+    team_id       integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    season        text NOT NULL CHECK (season ~ '^[0-9]{4}-[0-9]{2}$'),
+    name          varchar(255) NOT NULL,
+    shirt_number  smallint NOT NULL CHECK (shirt_number BETWEEN 1 AND 99),
+    position      text NOT NULL CHECK (position IN ('goalkeeper', 'defender', 'midfielder', 'forward')),
+    date_of_birth date,
+    nationality   char(2) CHECK (nationality ~ '^[A-Z]{2}$'),
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT players_shirt_number_key UNIQUE (team_id, season, shirt_number)
+);
+
+-- Los goles y tarjetas se pueden atribuir a un jugador. player conserva el
+-- nombre para la cronología y los eventos sin jugador registrado; un
+-- jugador con eventos no se puede eliminar.
+ALTER TABLE match_events ADD COLUMN IF NOT EXISTS player_id integer REFERENCES players (id);
+
+CREATE INDEX IF NOT EXISTS match_events_player_id_idx ON match_events (player_id) WHERE player_id IS NOT NULL;
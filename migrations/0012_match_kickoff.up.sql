@@ -0,0 +1,3 @@
+-- Hora de inicio de cada partido, opcional. match_date sigue siendo la fecha
+-- del partido; si hay hora de inicio, es la fecha local de esa hora.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS kickoff timestamptz;
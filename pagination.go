@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paginationSettings define el tamaño de página del listado de partidos
+type paginationSettings struct {
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// listRequest es un listado de partidos ya interpretado desde la query string
+type listRequest struct {
+	query  MatchQuery
+	cursor bool // paginación por cursor en lugar de offset
+}
+
+// parseListRequest interpreta los parámetros de GET /api/matches:
+// team, teamId, homeTeam, awayTeam, from, to, status, sort, limit, offset y cursor
+func parseListRequest(c *gin.Context, s paginationSettings, now time.Time) (listRequest, *FieldError) {
+	q := MatchQuery{
+		Team:     strings.TrimSpace(c.Query("team")),
+		HomeTeam: strings.TrimSpace(c.Query("homeTeam")),
+		AwayTeam: strings.TrimSpace(c.Query("awayTeam")),
+		Sort:     sortByID,
+		Limit:    s.DefaultLimit,
+	}
+
+	if v := c.Query("teamId"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return listRequest{}, &FieldError{Field: "teamId", Code: fieldInvalidValue, Message: tr(c, "field.integer")}
+		}
+		q.TeamID = n
+	}
+
+	for _, param := range []struct {
+		name string
+		dest *time.Time
+	}{{"from", &q.From}, {"to", &q.To}} {
+		if v := c.Query(param.name); v != "" {
+			d, err := time.Parse(time.DateOnly, v)
+			if err != nil {
+				return listRequest{}, &FieldError{Field: param.name, Code: codeInvalidDate, Message: tr(c, "field.date_format")}
+			}
+			*param.dest = d
+		}
+	}
+	if status := c.Query("status"); status != "" {
+		from, to, err := statusDateRange(status, now)
+		if err != nil {
+			return listRequest{}, &FieldError{Field: "status", Code: fieldInvalidValue, Message: tr(c, "field.status", statusScheduled, statusLive, statusFinished)}
+		}
+		if !from.IsZero() && from.After(q.From) {
+			q.From = from
+		}
+		if !to.IsZero() && (q.To.IsZero() || to.Before(q.To)) {
+			q.To = to
+		}
+	}
+
+	if v := c.Query("sort"); v != "" {
+		q.Sort, q.Desc = strings.TrimPrefix(v, "-"), strings.HasPrefix(v, "-")
+		if q.Sort != sortByID && q.Sort != sortByDate {
+			return listRequest{}, &FieldError{Field: "sort", Code: fieldInvalidValue, Message: tr(c, "field.sort")}
+		}
+	}
+
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > s.MaxLimit {
+			return listRequest{}, limitError(c, s)
+		}
+		q.Limit = n
+	}
+
+	// Un cursor vacío (?cursor=) inicia la paginación por cursor desde el principio
+	offset := c.Query("offset")
+	cursor, cursorMode := c.GetQuery("cursor")
+	if offset != "" && cursorMode {
+		return listRequest{}, &FieldError{Field: "cursor", Code: fieldInvalidValue, Message: tr(c, "field.cursor_with_offset")}
+	}
+	if offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return listRequest{}, &FieldError{Field: "offset", Code: fieldInvalidValue, Message: tr(c, "field.offset")}
+		}
+		q.Offset = n
+	}
+	if cursor != "" {
+		after, err := decodeCursor(cursor, q)
+		if err != nil {
+			key := "field.cursor_invalid"
+			if errors.Is(err, errCursorSort) {
+				key = "field.cursor_sort"
+			}
+			return listRequest{}, &FieldError{Field: "cursor", Code: fieldInvalidValue, Message: tr(c, key)}
+		}
+		q.After = &after
+	}
+	return listRequest{query: q, cursor: cursorMode}, nil
+}
+
+// limitError es el error de un limit fuera de 1..MaxLimit
+func limitError(c *gin.Context, s paginationSettings) *FieldError {
+	return &FieldError{Field: "limit", Code: fieldInvalidValue, Message: tr(c, "field.limit", s.MaxLimit)}
+}
+
+// statusDateRange traduce un estado de partido al rango de fechas que le
+// corresponde según matchStatus; un extremo cero no limita
+func statusDateRange(status string, now time.Time) (from, to time.Time, err error) {
+	today := matchToday(now)
+	switch status {
+	case statusScheduled:
+		return today.AddDate(0, 0, 1), time.Time{}, nil
+	case statusLive:
+		return today, today, nil
+	case statusFinished:
+		return time.Time{}, today.AddDate(0, 0, -1), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("estado desconocido %q", status)
+}
+
+// cursorData es el contenido de un cursor. Incluye el orden con el que se
+// generó para rechazarlo si se usa con otro.
+type cursorData struct {
+	Sort string `json:"s"`
+	Desc bool   `json:"d,omitempty"`
+	ID   int    `json:"i"`
+	Date string `json:"t,omitempty"`
+}
+
+// Errores de decodeCursor
+var (
+	errInvalidCursor = errors.New("cursor inválido")
+	errCursorSort    = errors.New("el cursor se generó con otro orden")
+)
+
+// encodeCursor genera el cursor opaco que apunta después de m
+func encodeCursor(m Match, q MatchQuery) string {
+	data := cursorData{Sort: q.Sort, Desc: q.Desc, ID: m.ID}
+	if q.Sort == sortByDate {
+		data.Date = m.MatchDate.Format(time.DateOnly)
+	}
+	b, _ := json.Marshal(data)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(cursor string, q MatchQuery) (MatchCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return MatchCursor{}, errInvalidCursor
+	}
+	var data cursorData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return MatchCursor{}, errInvalidCursor
+	}
+	if data.Sort != q.Sort || data.Desc != q.Desc {
+		return MatchCursor{}, errCursorSort
+	}
+	after := MatchCursor{ID: data.ID}
+	if q.Sort == sortByDate {
+		if after.MatchDate, err = time.Parse(time.DateOnly, data.Date); err != nil {
+			return MatchCursor{}, errInvalidCursor
+		}
+	}
+	return after, nil
+}
+
+// linkHeader arma la cabecera Link (RFC 8288) con las páginas vecinas. Con
+// cursor solo se conocen la primera y la siguiente.
+func linkHeader(u *url.URL, req listRequest, page MatchPage, hasNext bool) string {
+	q := req.query
+	link := func(rel string, set map[string]string) string {
+		values := u.Query()
+		values.Del("offset")
+		values.Del("cursor")
+		values.Set("limit", strconv.Itoa(q.Limit))
+		for k, v := range set {
+			values.Set(k, v)
+		}
+		target := *u
+		target.RawQuery = values.Encode()
+		return fmt.Sprintf("<%s>; rel=\"%s\"", target.RequestURI(), rel)
+	}
+
+	if req.cursor {
+		links := []string{link("first", map[string]string{"cursor": ""})}
+		if hasNext {
+			last := page.Matches[len(page.Matches)-1]
+			links = append(links, link("next", map[string]string{"cursor": encodeCursor(last, q)}))
+		}
+		return strings.Join(links, ", ")
+	}
+
+	links := []string{link("first", nil)}
+	if q.Offset > 0 {
+		links = append(links, link("prev", map[string]string{"offset": strconv.Itoa(max(q.Offset-q.Limit, 0))}))
+	}
+	if hasNext {
+		links = append(links, link("next", map[string]string{"offset": strconv.Itoa(q.Offset + q.Limit)}))
+	}
+	lastOffset := 0
+	if page.Total > 0 {
+		lastOffset = (page.Total - 1) / q.Limit * q.Limit
+	}
+	links = append(links, link("last", map[string]string{"offset": strconv.Itoa(lastOffset)}))
+	return strings.Join(links, ", ")
+}
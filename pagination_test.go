@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+var linkPattern = regexp.MustCompile(`<([^>]*)>; rel="(\w+)"`)
+
+// links retorna los destinos de la cabecera Link por rel
+func links(header string) map[string]string {
+	rels := map[string]string{}
+	for _, m := range linkPattern.FindAllStringSubmatch(header, -1) {
+		rels[m[2]] = m[1]
+	}
+	return rels
+}
+
+// seedMatches crea n partidos en días consecutivos y retorna sus ids
+func seedMatches(s *testServer, n int) []int {
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = s.match(home, away, fmt.Sprintf("2025-04-%02d", i+1)).ID
+	}
+	return ids
+}
+
+func TestListMatchesOffsetLinks(t *testing.T) {
+	s := newTestServer(t)
+	seedMatches(s, 5)
+
+	tests := []struct {
+		target string
+		ids    []int
+		rels   map[string]string // rel -> offset esperado; "" si no debe estar
+	}{
+		{"/api/matches?limit=2", []int{1, 2}, map[string]string{"first": "", "prev": "-", "next": "2", "last": "4"}},
+		{"/api/matches?limit=2&offset=2", []int{3, 4}, map[string]string{"prev": "0", "next": "4", "last": "4"}},
+		{"/api/matches?limit=2&offset=4", []int{5}, map[string]string{"prev": "2", "next": "-", "last": "4"}},
+		{"/api/matches?limit=2&sort=-date", []int{5, 4}, map[string]string{"next": "2", "last": "4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := s.do(http.MethodGet, tt.target, nil)
+			expectStatus(t, rec, http.StatusOK)
+			if got := rec.Header().Get("X-Total-Count"); got != "5" {
+				t.Errorf("X-Total-Count %q, se esperaba 5", got)
+			}
+			if got := matchIDs(decode[[]Match](t, rec)); !slices.Equal(got, tt.ids) {
+				t.Errorf("ids %v, se esperaba %v", got, tt.ids)
+			}
+			rels := links(rec.Header().Get("Link"))
+			for rel, offset := range tt.rels {
+				target, ok := rels[rel]
+				switch {
+				case offset == "-" && ok:
+					t.Errorf("Link no debe incluir %s: %s", rel, target)
+				case offset != "-" && !ok:
+					t.Errorf("Link sin %s: %v", rel, rels)
+				case ok:
+					u, _ := url.Parse(target)
+					if got := u.Query().Get("offset"); got != offset || u.Query().Get("limit") != "2" {
+						t.Errorf("%s apunta a %s, se esperaba offset=%q y limit=2", rel, target, offset)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestListMatchesCursor(t *testing.T) {
+	for _, sort := range []string{"id", "-id", "date", "-date"} {
+		t.Run(sort, func(t *testing.T) {
+			s := newTestServer(t)
+			want := seedMatches(s, 5)
+			if sort[0] == '-' {
+				slices.Reverse(want)
+			}
+
+			var got []int
+			target := "/api/matches?limit=2&cursor=&sort=" + url.QueryEscape(sort)
+			for pages := 0; target != ""; pages++ {
+				if pages > 5 {
+					t.Fatalf("la paginación no terminó: %v", got)
+				}
+				rec := s.do(http.MethodGet, target, nil)
+				expectStatus(t, rec, http.StatusOK)
+				got = append(got, matchIDs(decode[[]Match](t, rec))...)
+				rels := links(rec.Header().Get("Link"))
+				if _, ok := rels["last"]; ok {
+					t.Errorf("con cursor Link no debe incluir last: %v", rels)
+				}
+				target = rels["next"]
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("ids %v, se esperaba %v", got, want)
+			}
+		})
+	}
+}
+
+func TestListMatchesInvalidQuery(t *testing.T) {
+	s := newTestServer(t)
+	seedMatches(s, 3)
+	otherSort := links(s.do(http.MethodGet, "/api/matches?limit=1&cursor=", nil).Header().Get("Link"))["next"]
+	u, _ := url.Parse(otherSort)
+
+	tests := []struct {
+		query string
+		code  string
+		field string
+	}{
+		{"limit=0", codeInvalidQuery, "limit"},
+		{"limit=101", codeInvalidQuery, "limit"},
+		{"offset=-1", codeInvalidQuery, "offset"},
+		{"offset=1&cursor=", codeInvalidQuery, "cursor"},
+		{"cursor=no-es-un-cursor", codeInvalidQuery, "cursor"},
+		{"sort=-date&cursor=" + url.QueryEscape(u.Query().Get("cursor")), codeInvalidQuery, "cursor"},
+		{"sort=goals", codeInvalidQuery, "sort"},
+		{"status=suspended", codeInvalidQuery, "status"},
+		{"teamId=x", codeInvalidQuery, "teamId"},
+		{"from=01-04-2025", codeInvalidDate, "from"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			p := expectProblem(t, s.do(http.MethodGet, "/api/matches?"+tt.query, nil), http.StatusBadRequest, tt.code)
+			if len(p.Errors) != 1 || p.Errors[0].Field != tt.field {
+				t.Errorf("errores %+v, se esperaba el campo %s", p.Errors, tt.field)
+			}
+		})
+	}
+}
+
+func matchIDs(matches []Match) []int {
+	ids := make([]int, len(matches))
+	for i, m := range matches {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+// TestStatusDateRangeMatchesStatus verifica que el filtro por estado incluya
+// justo los partidos a los que matchStatus asigna ese estado, también cuando
+// now está en otro día en su zona horaria que en UTC
+func TestStatusDateRangeMatchesStatus(t *testing.T) {
+	nows := []time.Time{
+		time.Date(2025, 4, 2, 12, 0, 0, 0, time.UTC),
+		time.Date(2025, 4, 1, 23, 30, 0, 0, time.FixedZone("ART", -3*60*60)),
+		time.Date(2025, 4, 2, 0, 30, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	}
+	for _, now := range nows {
+		for _, day := range []string{"2025-03-31", "2025-04-01", "2025-04-02", "2025-04-03"} {
+			date, _ := time.Parse(time.DateOnly, day)
+			status := matchStatus(date, now)
+			from, to, err := statusDateRange(status, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !from.IsZero() && date.Before(from) || !to.IsZero() && date.After(to) {
+				t.Errorf("now %v: el partido del %s es %s pero el filtro va de %v a %v", now, day, status, from, to)
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Tipos de documento que admite PATCH /api/matches/{id}
+const (
+	mergePatchContentType = "application/merge-patch+json" // RFC 7396
+	jsonPatchContentType  = "application/json-patch+json"  // RFC 6902
+)
+
+// acceptPatch es el valor de la cabecera Accept-Patch (RFC 5789)
+var acceptPatch = mergePatchContentType + ", " + jsonPatchContentType
+
+// maxPatchBytes limita el tamaño del documento de cambios
+const maxPatchBytes = 64 << 10
+
+// patchVoidReason es el motivo de los eventos que anula un parche al
+// descontar un contador
+const patchVoidReason = "corrección con PATCH /api/matches/{id}"
+
+// matchDocument es el partido tal como lo ve un documento de cambios. Todos
+// los campos se serializan, incluso en cero, para que las rutas de JSON Patch
+// como /goals siempre existan.
+type matchDocument struct {
+	ID          *int    `json:"id" binding:"required"`
+	HomeTeamID  *int    `json:"homeTeamId" binding:"required,min=1"`
+	HomeTeam    *string `json:"homeTeam" binding:"required"`
+	AwayTeamID  *int    `json:"awayTeamId" binding:"required,min=1"`
+	AwayTeam    *string `json:"awayTeam" binding:"required"`
+	MatchDate   *string `json:"matchDate" binding:"required"`
+	Goals       *int    `json:"goals" binding:"required,min=0"`
+	HomeScore   *int    `json:"homeScore" binding:"required,min=0"`
+	AwayScore   *int    `json:"awayScore" binding:"required,min=0"`
+	YellowCards *int    `json:"yellowCards" binding:"required,min=0"`
+	RedCards    *int    `json:"redCards" binding:"required,min=0"`
+	ExtraTime   *int    `json:"extraTime" binding:"required,min=0,max=30"`
+}
+
+func newMatchDocument(m Match) matchDocument {
+	date := m.MatchDate.Format(time.DateOnly)
+	return matchDocument{
+		ID: &m.ID, HomeTeamID: &m.HomeTeamID, HomeTeam: &m.HomeTeam, AwayTeamID: &m.AwayTeamID, AwayTeam: &m.AwayTeam, MatchDate: &date,
+		Goals: &m.Goals, HomeScore: &m.HomeScore, AwayScore: &m.AwayScore, YellowCards: &m.YellowCards, RedCards: &m.RedCards, ExtraTime: &m.ExtraTime,
+	}
+}
+
+// applyMatchPatch aplica el documento de cambios según su tipo
+func applyMatchPatch(contentType string, doc, patch []byte) ([]byte, error) {
+	if contentType == jsonPatchContentType {
+		ops, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, err
+		}
+		return ops.Apply(doc)
+	}
+	return jsonpatch.MergePatch(doc, patch)
+}
+
+// patchedMatch valida el partido resultante del parche y lo convierte en
+// Match. Los errores se reportan por campo como en el resto de la API.
+func patchedMatch(c *gin.Context, current Match, patched []byte) (Match, []FieldError) {
+	var doc matchDocument
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			field := typeErr.Field
+			if field == "" {
+				field = "/"
+			}
+			return Match{}, []FieldError{{Field: field, Code: fieldInvalidType, Message: tr(c, "field.type", typeErr.Type.String())}}
+		}
+		if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return Match{}, []FieldError{{Field: strings.Trim(name, `"`), Code: fieldInvalidValue, Message: tr(c, "field.unknown")}}
+		}
+		return Match{}, []FieldError{{Field: "/", Code: fieldInvalidType, Message: tr(c, "field.type", "object")}}
+	}
+
+	var fields []FieldError
+	var validationErrs validator.ValidationErrors
+	if err := binding.Validator.ValidateStruct(&doc); errors.As(err, &validationErrs) {
+		for _, fe := range validationErrs {
+			fields = append(fields, validationFieldError(c, fe))
+		}
+	}
+	if doc.ID != nil && *doc.ID != current.ID {
+		fields = append(fields, FieldError{Field: "id", Code: fieldInvalidValue, Message: tr(c, "field.read_only")})
+	}
+	// Los nombres de los equipos son los de sus registros en /api/teams
+	if doc.HomeTeam != nil && *doc.HomeTeam != current.HomeTeam {
+		fields = append(fields, FieldError{Field: "homeTeam", Code: fieldInvalidValue, Message: tr(c, "field.team_name_read_only", "homeTeamId")})
+	}
+	if doc.AwayTeam != nil && *doc.AwayTeam != current.AwayTeam {
+		fields = append(fields, FieldError{Field: "awayTeam", Code: fieldInvalidValue, Message: tr(c, "field.team_name_read_only", "awayTeamId")})
+	}
+	if doc.HomeTeamID != nil && doc.AwayTeamID != nil && *doc.HomeTeamID == *doc.AwayTeamID {
+		fields = append(fields, *sameTeamError(c, ""))
+	}
+	// Si el parche no cambia goals, el total sigue a los marcadores; si lo
+	// cambia debe alcanzar para ambos, por ejemplo al asignar goles sin equipo
+	var goals int
+	if doc.Goals != nil && doc.HomeScore != nil && doc.AwayScore != nil {
+		goals = current.unattributedGoals() + *doc.HomeScore + *doc.AwayScore
+		if *doc.Goals != current.Goals {
+			goals = *doc.Goals
+		}
+		if goals < *doc.HomeScore+*doc.AwayScore {
+			fields = append(fields, FieldError{Field: "goals", Code: fieldInvalidValue, Message: tr(c, "field.goals_total")})
+		}
+	}
+	var date time.Time
+	if doc.MatchDate != nil {
+		var err error
+		if date, err = time.Parse(time.DateOnly, *doc.MatchDate); err != nil {
+			fields = append(fields, FieldError{Field: "matchDate", Code: codeInvalidDate, Message: tr(c, "field.date_format")})
+		}
+	}
+	if len(fields) > 0 {
+		return Match{}, fields
+	}
+	// La hora de inicio no se edita con PATCH y deja de valer si cambia la fecha
+	var kickoff *time.Time
+	if date.Equal(current.MatchDate) {
+		kickoff = current.Kickoff
+	}
+
+	return Match{
+		ID:          current.ID,
+		HomeTeamID:  *doc.HomeTeamID,
+		AwayTeamID:  *doc.AwayTeamID,
+		MatchDate:   date,
+		Goals:       goals,
+		HomeScore:   *doc.HomeScore,
+		AwayScore:   *doc.AwayScore,
+		YellowCards: *doc.YellowCards,
+		RedCards:    *doc.RedCards,
+		ExtraTime:   *doc.ExtraTime,
+		Kickoff:     kickoff,
+	}, nil
+}
+
+// patchMatch godoc
+// @Summary Modificar parcialmente un partido
+// @Description Aplica un JSON Merge Patch (RFC 7396) o un JSON Patch (RFC 6902) según el Content-Type.
+// @Description Se pueden modificar homeTeamId, awayTeamId, matchDate (YYYY-MM-DD), homeScore, awayScore, yellowCards, redCards y extraTime;
+// @Description el resultado se valida completo antes de guardarlo. El id y los nombres de los equipos no se pueden cambiar.
+// @Description Si goals no cambia se recalcula con homeScore y awayScore; si cambia debe ser al menos su suma.
+// @Description Cada cambio de un contador se registra en la cronología: lo que aumenta como eventos sin minuto
+// @Description y lo que disminuye anulando los últimos eventos vigentes.
+// @Description Con If-Match el parche solo se aplica si el partido no cambió desde que se leyó.
+// @Tags matches
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Param patch body object true "Documento de cambios"
+// @Param If-Match header string false "ETag leído del partido"
+// @Success 200 {object} Match
+// @Header 200 {string} ETag "Nueva versión del partido"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 412 {object} Problem
+// @Failure 415 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 428 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id} [patch]
+func (a *app) patchMatch(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if contentType != mergePatchContentType && contentType != jsonPatchContentType {
+		c.Header("Accept-Patch", acceptPatch)
+		respondProblem(c, codeUnsupportedMediaType, tr(c, "detail.unsupported_patch", acceptPatch))
+		return
+	}
+	if !a.requireIfMatch(c) {
+		return
+	}
+	patch, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchBytes))
+	if err != nil {
+		c.Error(err)
+		respondProblem(c, codeMalformedBody, tr(c, "detail.unreadable_body"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	current, err := a.store.GetMatch(ctx, matchID)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	if !checkIfMatch(c, current) {
+		return
+	}
+	doc, _ := json.Marshal(newMatchDocument(current))
+
+	patched, err := applyMatchPatch(contentType, doc, patch)
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		respondProblem(c, codePatchTestFailed, err.Error())
+		return
+	case errors.Is(err, jsonpatch.ErrMissing), errors.Is(err, jsonpatch.ErrInvalidIndex):
+		respondProblem(c, codePatchNotApplicable, err.Error())
+		return
+	case err != nil:
+		respondProblem(c, codeMalformedBody, tr(c, "detail.invalid_patch", contentType))
+		return
+	}
+
+	match, fields := patchedMatch(c, current, patched)
+	if len(fields) > 0 {
+		respondProblem(c, codeInvalidPatchResult, tr(c, "detail.invalid_patch_result"), fields...)
+		return
+	}
+
+	// La versión leída hace que un cambio concurrente entre la lectura y la
+	// escritura resulte en 412 en lugar de perderse; también fija los
+	// contadores desde los que se calculan los eventos de la corrección
+	match.Version = current.Version
+	match, err = a.store.CorrectMatch(ctx, match, patchVoidReason)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	setMatchETag(c, match)
+	c.IndentedJSON(http.StatusOK, match)
+}
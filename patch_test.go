@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatchMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		patch       string
+		check       func(t *testing.T, m Match, teams []Team)
+	}{
+		{"merge patch de la fecha", mergePatchContentType, `{"matchDate":"2025-05-01"}`, func(t *testing.T, m Match, _ []Team) {
+			if got := m.MatchDate.Format("2006-01-02"); got != "2025-05-01" {
+				t.Errorf("matchDate %s, se esperaba 2025-05-01", got)
+			}
+		}},
+		{"JSON Patch del visitante", jsonPatchContentType, `[{"op":"test","path":"/awayTeamId","value":2},{"op":"replace","path":"/awayTeamId","value":3}]`, func(t *testing.T, m Match, teams []Team) {
+			if m.AwayTeamID != teams[2].ID || m.AwayTeam != teams[2].Name {
+				t.Errorf("visitante %d %q, se esperaba %+v", m.AwayTeamID, m.AwayTeam, teams[2])
+			}
+		}},
+		{"merge patch de contadores", mergePatchContentType, `{"goals":3,"homeScore":2,"awayScore":1}`, func(t *testing.T, m Match, _ []Team) {
+			if m.Goals != 3 || m.HomeScore != 2 || m.AwayScore != 1 {
+				t.Errorf("contadores %+v, se esperaba 3 goles y 2-1", m)
+			}
+		}},
+		{"Content-Type con parámetros", mergePatchContentType + "; charset=utf-8", `{}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			teams := []Team{s.team("Barcelona"), s.team("Real Madrid"), s.team("Sevilla")}
+			m := s.match(teams[0], teams[1], "2025-04-01")
+
+			rec := s.do(http.MethodPatch, fmt.Sprintf("/api/matches/%d", m.ID), tt.patch, "Content-Type", tt.contentType)
+			expectStatus(t, rec, http.StatusOK)
+			got := decode[Match](t, rec)
+			if got.Version != m.Version+1 {
+				t.Errorf("versión %d, se esperaba %d", got.Version, m.Version+1)
+			}
+			if rec.Header().Get("ETag") == "" {
+				t.Error("la respuesta no incluye ETag")
+			}
+			if tt.check != nil {
+				tt.check(t, got, teams)
+			}
+		})
+	}
+}
+
+// TestPatchMatchRecordsEvents verifica que corregir un contador con PATCH
+// deje la corrección en la cronología
+// TestPatchMatchKickoff verifica que PATCH, que no edita la hora de inicio,
+// la conserve salvo que cambie la fecha
+func TestPatchMatchKickoff(t *testing.T) {
+	tests := []struct {
+		patch string
+		keep  bool
+	}{
+		{`{"homeScore":1}`, true},
+		{`{"matchDate":"2025-04-01"}`, true},
+		{`{"matchDate":"2025-05-01"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.patch, func(t *testing.T) {
+			s := newTestServer(t)
+			home, away := s.team("Barcelona"), s.team("Real Madrid")
+			kickoff := time.Date(2025, 4, 1, 19, 0, 0, 0, time.UTC)
+			m, err := s.store.CreateMatch(t.Context(), MatchInput{HomeTeamID: home.ID, AwayTeamID: away.ID, MatchDate: kickoff.Truncate(24 * time.Hour), Kickoff: &kickoff})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rec := s.do(http.MethodPatch, fmt.Sprintf("/api/matches/%d", m.ID), tt.patch, "Content-Type", mergePatchContentType)
+			expectStatus(t, rec, http.StatusOK)
+			got, err := s.store.GetMatch(t.Context(), m.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kept := got.Kickoff != nil && got.Kickoff.Equal(kickoff); kept != tt.keep {
+				t.Errorf("kickoff %v, se esperaba conservarla: %v", got.Kickoff, tt.keep)
+			}
+		})
+	}
+}
+
+func TestPatchMatchRecordsEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		legacy   func(m *Match) // contadores sin eventos, anteriores a la cronología
+		events   []MatchEventInput
+		patch    string
+		counters []int
+		timeline []string // tipo, equipo y si el evento está anulado
+	}{
+		{"sube y baja contadores", func(m *Match) { m.RedCards = 1 },
+			[]MatchEventInput{{Type: eventGoal, Team: sideHome}, {Type: eventYellowCard, Team: sideAway}},
+			`{"homeScore":0,"awayScore":2,"yellowCards":2,"redCards":0}`,
+			[]int{2, 0, 2, 2, 0, 0},
+			[]string{"goal/home/anulado", "yellow_card/away/", "goal/away/", "goal/away/", "yellow_card//", "red_card//anulado"}},
+		{"descuenta goles sin equipo", func(m *Match) { m.Goals = 3 },
+			nil,
+			`{"homeScore":1,"goals":1}`,
+			[]int{1, 1, 0, 0, 0, 0},
+			[]string{"goal/home/", "goal//anulado", "goal//anulado", "goal//anulado"}},
+		{"tiempo extra", nil,
+			[]MatchEventInput{{Type: eventExtraTime}, {Type: eventExtraTime}},
+			`{"extraTime":1}`,
+			[]int{0, 0, 0, 0, 0, 1},
+			[]string{"extra_time//", "extra_time//anulado"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			m := s.match(s.team("Barcelona"), s.team("Real Madrid"), "2025-04-01")
+			if tt.legacy != nil {
+				tt.legacy(&m)
+				if _, err := s.store.ReplaceMatch(t.Context(), m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, in := range tt.events {
+				in.MatchID = m.ID
+				if _, _, err := s.store.AddMatchEvent(t.Context(), in); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			rec := s.do(http.MethodPatch, fmt.Sprintf("/api/matches/%d", m.ID), tt.patch, "Content-Type", mergePatchContentType)
+			expectStatus(t, rec, http.StatusOK)
+			if got := counters(decode[Match](t, rec)); !slices.Equal(got, tt.counters) {
+				t.Errorf("contadores %v, se esperaba %v", got, tt.counters)
+			}
+
+			events, err := s.store.ListMatchEvents(t.Context(), m.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var timeline []string
+			for _, e := range events {
+				voided := ""
+				if e.VoidedAt != nil {
+					voided = "anulado"
+					if e.VoidReason != patchVoidReason {
+						t.Errorf("motivo %q, se esperaba %q", e.VoidReason, patchVoidReason)
+					}
+				}
+				timeline = append(timeline, e.Type+"/"+e.Team+"/"+voided)
+			}
+			if !slices.Equal(timeline, tt.timeline) {
+				t.Errorf("cronología %v, se esperaba %v", timeline, tt.timeline)
+			}
+		})
+	}
+}
+
+func TestPatchMatchErrors(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	m := s.match(home, away, "2025-04-01")
+	target := fmt.Sprintf("/api/matches/%d", m.ID)
+
+	tests := []struct {
+		name        string
+		contentType string
+		patch       string
+		status      int
+		code        string
+		field       string
+	}{
+		{"Content-Type no admitido", "application/json", `{}`, http.StatusUnsupportedMediaType, codeUnsupportedMediaType, ""},
+		{"documento inválido", mergePatchContentType, `{"matchDate":`, http.StatusBadRequest, codeMalformedBody, ""},
+		{"JSON Patch que no es una lista", jsonPatchContentType, `{"op":"remove"}`, http.StatusBadRequest, codeMalformedBody, ""},
+		{"documento demasiado grande", mergePatchContentType, `{"x":"` + strings.Repeat("a", maxPatchBytes) + `"}`, http.StatusBadRequest, codeMalformedBody, ""},
+		{"operación test fallida", jsonPatchContentType, `[{"op":"test","path":"/homeTeamId","value":99}]`, http.StatusConflict, codePatchTestFailed, ""},
+		{"ruta inexistente", jsonPatchContentType, `[{"op":"remove","path":"/stadium"}]`, http.StatusUnprocessableEntity, codePatchNotApplicable, ""},
+		{"id de solo lectura", mergePatchContentType, `{"id":99}`, http.StatusUnprocessableEntity, codeInvalidPatchResult, "id"},
+		{"nombre de equipo de solo lectura", mergePatchContentType, `{"homeTeam":"Barça"}`, http.StatusUnprocessableEntity, codeInvalidPatchResult, "homeTeam"},
+		{"campo desconocido", mergePatchContentType, `{"stadium":"Camp Nou"}`, http.StatusUnprocessableEntity, codeInvalidPatchResult, "stadium"},
+		{"campo eliminado", mergePatchContentType, `{"matchDate":null}`, http.StatusUnprocessableEntity, codeInvalidPatchResult, "matchDate"},
+		{"tipo inválido", mergePatchContentType, `{"homeTeamId":"1"}`, http.StatusUnprocessableEntity, codeInvalidPatchResult, "homeTeamId"},
+		{"fecha inválida", mergePatchContentType, `{"matchDate":"01/05/2025"}`, http.StatusUnprocessableEntity, codeInvalidPatchResult, "matchDate"},
+		{"mismo equipo", mergePatchContentType, fmt.Sprintf(`{"awayTeamId":%d}`, home.ID), http.StatusUnprocessableEntity, codeInvalidPatchResult, "awayTeamId"},
+		{"equipo desconocido", mergePatchContentType, `{"awayTeamId":999}`, http.StatusUnprocessableEntity, codeUnknownTeam, ""},
+		{"contador negativo", mergePatchContentType, `{"redCards":-1}`, http.StatusUnprocessableEntity, codeInvalidPatchResult, "redCards"},
+		{"tiempo extra fuera de rango", mergePatchContentType, `{"extraTime":31}`, http.StatusUnprocessableEntity, codeInvalidPatchResult, "extraTime"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(http.MethodPatch, target, tt.patch, "Content-Type", tt.contentType)
+			p := expectProblem(t, rec, tt.status, tt.code)
+			if tt.status == http.StatusUnsupportedMediaType && rec.Header().Get("Accept-Patch") != acceptPatch {
+				t.Errorf("Accept-Patch %q, se esperaba %q", rec.Header().Get("Accept-Patch"), acceptPatch)
+			}
+			if tt.field != "" && (len(p.Errors) != 1 || p.Errors[0].Field != tt.field) {
+				t.Errorf("errores %+v, se esperaba el campo %s", p.Errors, tt.field)
+			}
+		})
+	}
+
+	got, err := s.store.GetMatch(t.Context(), m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != m.Version {
+		t.Errorf("un parche rechazado cambió el partido: %+v", got)
+	}
+}
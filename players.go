@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Player es un jugador en la plantilla de un equipo durante una temporada. El
+// mismo futbolista en otra temporada u otro equipo es otro registro.
+// @Description Jugador de la plantilla de un equipo en una temporada, con los goles y tarjetas que se le atribuyeron
+type Player struct {
+	ID          int         `json:"id"`
+	TeamID      int         `json:"teamId"`
+	Season      string      `json:"season" example:"2024-25"`
+	Name        string      `json:"name" example:"Pedri"`
+	ShirtNumber int         `json:"shirtNumber" example:"8"`
+	Position    string      `json:"position" enums:"goalkeeper,defender,midfielder,forward"`
+	DateOfBirth string      `json:"dateOfBirth,omitempty" example:"2002-11-25"`
+	Nationality string      `json:"nationality,omitempty" example:"ES"`
+	Stats       PlayerStats `json:"stats"`
+}
+
+// PlayerStats cuenta los eventos vigentes atribuidos a un jugador
+type PlayerStats struct {
+	Goals       int `json:"goals"`
+	YellowCards int `json:"yellowCards"`
+	RedCards    int `json:"redCards"`
+}
+
+// PlayerInput contiene los datos editables de un jugador
+type PlayerInput struct {
+	TeamID      int
+	Season      string
+	Name        string
+	ShirtNumber int
+	Position    string
+	DateOfBirth string // YYYY-MM-DD o vacío
+	Nationality string
+}
+
+// playerRequest es el cuerpo para crear o reemplazar un jugador
+type playerRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	ShirtNumber int    `json:"shirtNumber" binding:"required,min=1,max=99"`
+	Position    string `json:"position" binding:"required,oneof=goalkeeper defender midfielder forward" enums:"goalkeeper,defender,midfielder,forward"`
+	Season      string `json:"season" example:"2024-25"`
+	DateOfBirth string `json:"dateOfBirth" example:"2002-11-25"`
+	Nationality string `json:"nationality" binding:"omitempty,iso3166_1_alpha2" example:"ES"`
+}
+
+// seasonOf retorna la temporada de date, por ejemplo "2024-25". Las
+// temporadas van de julio a junio.
+func seasonOf(date time.Time) string {
+	year := date.Year()
+	if date.Month() < time.July {
+		year--
+	}
+	return fmt.Sprintf("%d-%02d", year, (year+1)%100)
+}
+
+// validSeason indica si season tiene el formato de seasonOf con dos años
+// consecutivos
+func validSeason(season string) bool {
+	start, end, ok := strings.Cut(season, "-")
+	if !ok || len(start) != 4 || len(end) != 2 {
+		return false
+	}
+	first, err := strconv.Atoi(start)
+	if err != nil {
+		return false
+	}
+	second, err := strconv.Atoi(end)
+	return err == nil && second == (first+1)%100
+}
+
+// bindPlayerRequest lee y valida el cuerpo de un jugador de teamID; responde
+// el error si no es válido. Sin season el jugador es de la temporada actual.
+func bindPlayerRequest(c *gin.Context, teamID int) (PlayerInput, bool) {
+	var req playerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return PlayerInput{}, false
+	}
+	in := PlayerInput{
+		TeamID:      teamID,
+		Season:      req.Season,
+		Name:        strings.Join(strings.Fields(req.Name), " "),
+		ShirtNumber: req.ShirtNumber,
+		Position:    req.Position,
+		DateOfBirth: req.DateOfBirth,
+		Nationality: req.Nationality,
+	}
+	if in.Season == "" {
+		in.Season = seasonOf(time.Now())
+	}
+
+	var fields []FieldError
+	if in.Name == "" {
+		fields = append(fields, FieldError{Field: "name", Code: fieldRequired, Message: tr(c, "validation.required")})
+	}
+	if !validSeason(in.Season) {
+		fields = append(fields, FieldError{Field: "season", Code: fieldInvalidValue, Message: tr(c, "field.season")})
+	}
+	if in.DateOfBirth != "" {
+		if _, err := time.Parse(time.DateOnly, in.DateOfBirth); err != nil {
+			fields = append(fields, FieldError{Field: "dateOfBirth", Code: codeInvalidDate, Message: tr(c, "field.date_format")})
+		}
+	}
+	if len(fields) > 0 {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"), fields...)
+		return PlayerInput{}, false
+	}
+	return in, true
+}
+
+// attributeTo completa el evento con el equipo y el nombre del jugador p. El
+// jugador debe ser de la plantilla de uno de los equipos de m en la temporada
+// del partido y, si el evento ya indica el equipo, de ese lado.
+func (in *MatchEventInput) attributeTo(m Match, p Player) error {
+	var side string
+	switch p.TeamID {
+	case m.HomeTeamID:
+		side = sideHome
+	case m.AwayTeamID:
+		side = sideAway
+	}
+	if side == "" || p.Season != seasonOf(m.MatchDate) || in.Team != "" && in.Team != side {
+		return ErrPlayerNotInMatch
+	}
+	in.Team, in.Player = side, p.Name
+	return nil
+}
+
+// teamPlayerIDs lee los ids de equipo y jugador de la ruta; responde el
+// error y retorna false si alguno no es válido
+func teamPlayerIDs(c *gin.Context) (teamID, playerID int, ok bool) {
+	if teamID, ok = parsePathID(c, "id"); !ok {
+		return 0, 0, false
+	}
+	if playerID, ok = parsePathID(c, "playerId"); !ok {
+		return 0, 0, false
+	}
+	return teamID, playerID, true
+}
+
+// listPlayers godoc
+// @Summary Plantilla de un equipo
+// @Description Retorna los jugadores del equipo en la temporada ordenados por dorsal, con los goles y tarjetas atribuidos.
+// @Description Sin season retorna la temporada actual.
+// @Tags teams
+// @Produce json
+// @Param id path int true "ID del Equipo"
+// @Param season query string false "Temporada, por ejemplo 2024-25"
+// @Success 200 {array} Player
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams/{id}/players [get]
+func (a *app) listPlayers(c *gin.Context) {
+	teamID, ok := parsePathID(c, "id")
+	if !ok {
+		return
+	}
+	season := c.DefaultQuery("season", seasonOf(time.Now()))
+	if !validSeason(season) {
+		fieldErr := FieldError{Field: "season", Code: fieldInvalidValue, Message: tr(c, "field.season")}
+		respondProblem(c, codeInvalidQuery, fieldErr.Error(), fieldErr)
+		return
+	}
+	players, err := a.store.ListPlayers(c.Request.Context(), teamID, season)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, players)
+}
+
+// getPlayer godoc
+// @Summary Obtener un jugador
+// @Tags teams
+// @Produce json
+// @Param id path int true "ID del Equipo"
+// @Param playerId path int true "ID del Jugador"
+// @Success 200 {object} Player
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams/{id}/players/{playerId} [get]
+func (a *app) getPlayer(c *gin.Context) {
+	teamID, playerID, ok := teamPlayerIDs(c)
+	if !ok {
+		return
+	}
+	player, err := a.store.GetPlayer(c.Request.Context(), teamID, playerID)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, player)
+}
+
+// createPlayer godoc
+// @Summary Agregar un jugador a la plantilla
+// @Description El dorsal no se puede repetir en el equipo durante la temporada. Sin season el jugador es de la temporada actual.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Equipo"
+// @Param player body playerRequest true "Datos del jugador"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 201 {object} Player
+// @Header 201 {string} Location "URL del jugador creado"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams/{id}/players [post]
+func (a *app) createPlayer(c *gin.Context) {
+	teamID, ok := parsePathID(c, "id")
+	if !ok {
+		return
+	}
+	in, ok := bindPlayerRequest(c, teamID)
+	if !ok {
+		return
+	}
+	player, err := a.store.CreatePlayer(c.Request.Context(), in)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.Header("Location", fmt.Sprintf("/api/teams/%d/players/%d", teamID, player.ID))
+	c.IndentedJSON(http.StatusCreated, player)
+}
+
+// updatePlayer godoc
+// @Summary Reemplazar los datos de un jugador
+// @Description Si cambia el nombre, se actualiza en los eventos atribuidos al jugador.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Equipo"
+// @Param playerId path int true "ID del Jugador"
+// @Param player body playerRequest true "Datos del jugador"
+// @Success 200 {object} Player
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams/{id}/players/{playerId} [put]
+func (a *app) updatePlayer(c *gin.Context) {
+	teamID, playerID, ok := teamPlayerIDs(c)
+	if !ok {
+		return
+	}
+	in, ok := bindPlayerRequest(c, teamID)
+	if !ok {
+		return
+	}
+	player, err := a.store.UpdatePlayer(c.Request.Context(), playerID, in)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, player)
+}
+
+// deletePlayer godoc
+// @Summary Quitar un jugador de la plantilla
+// @Description Solo se puede eliminar un jugador sin goles ni tarjetas atribuidos, incluidos los anulados
+// @Tags teams
+// @Param id path int true "ID del Equipo"
+// @Param playerId path int true "ID del Jugador"
+// @Success 204
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams/{id}/players/{playerId} [delete]
+func (a *app) deletePlayer(c *gin.Context) {
+	teamID, playerID, ok := teamPlayerIDs(c)
+	if !ok {
+		return
+	}
+	if err := a.store.DeletePlayer(c.Request.Context(), teamID, playerID); err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// player crea un jugador de team directamente en el almacenamiento
+func (s *testServer) player(team Team, name string, shirtNumber int, season string) Player {
+	s.t.Helper()
+	p, err := s.store.CreatePlayer(s.t.Context(), PlayerInput{TeamID: team.ID, Season: season, Name: name, ShirtNumber: shirtNumber, Position: "forward"})
+	if err != nil {
+		s.t.Fatalf("CreatePlayer(%q): %v", name, err)
+	}
+	return p
+}
+
+func TestCreatePlayer(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Barcelona")
+	s.player(team, "Lewandowski", 9, "2024-25")
+	players := fmt.Sprintf("/api/teams/%d/players", team.ID)
+
+	body := map[string]any{"name": " Pedri ", "shirtNumber": 8, "position": "midfielder", "season": "2024-25", "dateOfBirth": "2002-11-25", "nationality": "ES"}
+	rec := s.do(http.MethodPost, players, body)
+	expectStatus(t, rec, http.StatusCreated)
+	p := decode[Player](t, rec)
+	if p.TeamID != team.ID || p.Name != "Pedri" || p.Season != "2024-25" || p.DateOfBirth != "2002-11-25" {
+		t.Errorf("jugador %+v", p)
+	}
+	if got, want := rec.Header().Get("Location"), fmt.Sprintf("%s/%d", players, p.ID); got != want {
+		t.Errorf("Location %q, se esperaba %q", got, want)
+	}
+
+	// Sin season el jugador y la plantilla son de la temporada actual
+	rec = s.do(http.MethodPost, players, map[string]any{"name": "Lamine Yamal", "shirtNumber": 19, "position": "forward"})
+	expectStatus(t, rec, http.StatusCreated)
+	if got := decode[Player](t, rec); got.Season != seasonOf(time.Now()) {
+		t.Errorf("temporada %q, se esperaba la actual %q", got.Season, seasonOf(time.Now()))
+	}
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{players + "?season=2024-25", []string{"Pedri", "Lewandowski"}},
+		{players, []string{"Lamine Yamal"}},
+		{players + "?season=2023-24", nil},
+	}
+	for _, tt := range tests {
+		rec := s.do(http.MethodGet, tt.target, nil)
+		expectStatus(t, rec, http.StatusOK)
+		var names []string
+		for _, p := range decode[[]Player](t, rec) {
+			names = append(names, p.Name)
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("%s: jugadores %v, se esperaba %v", tt.target, names, tt.want)
+		}
+	}
+}
+
+func TestPlayerErrors(t *testing.T) {
+	s := newTestServer(t)
+	barcelona, madrid := s.team("Barcelona"), s.team("Real Madrid")
+	pedri := s.player(barcelona, "Pedri", 8, "2024-25")
+	players := fmt.Sprintf("/api/teams/%d/players", barcelona.ID)
+	valid := func(fields map[string]any) map[string]any {
+		body := map[string]any{"name": "Gavi", "shirtNumber": 6, "position": "midfielder", "season": "2024-25"}
+		for k, v := range fields {
+			body[k] = v
+		}
+		return body
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		status int
+		code   string
+		field  string
+	}{
+		{"sin nombre", http.MethodPost, players, valid(map[string]any{"name": " "}), http.StatusBadRequest, codeValidationFailed, "name"},
+		{"dorsal fuera de rango", http.MethodPost, players, valid(map[string]any{"shirtNumber": 100}), http.StatusBadRequest, codeValidationFailed, "shirtNumber"},
+		{"posición desconocida", http.MethodPost, players, valid(map[string]any{"position": "winger"}), http.StatusBadRequest, codeValidationFailed, "position"},
+		{"temporada sin años consecutivos", http.MethodPost, players, valid(map[string]any{"season": "2024-26"}), http.StatusBadRequest, codeValidationFailed, "season"},
+		{"temporada con otro formato", http.MethodPost, players, valid(map[string]any{"season": "2024"}), http.StatusBadRequest, codeValidationFailed, "season"},
+		{"fecha de nacimiento", http.MethodPost, players, valid(map[string]any{"dateOfBirth": "25/11/2002"}), http.StatusBadRequest, codeValidationFailed, "dateOfBirth"},
+		{"nacionalidad", http.MethodPost, players, valid(map[string]any{"nationality": "ESP"}), http.StatusBadRequest, codeValidationFailed, "nationality"},
+		{"dorsal repetido", http.MethodPost, players, valid(map[string]any{"shirtNumber": 8}), http.StatusConflict, codeShirtNumberTaken, ""},
+		{"temporada de la plantilla", http.MethodGet, players + "?season=24-25", nil, http.StatusBadRequest, codeInvalidQuery, ""},
+		{"equipo desconocido", http.MethodPost, "/api/teams/999/players", valid(nil), http.StatusNotFound, codeTeamNotFound, ""},
+		{"jugador de otro equipo", http.MethodGet, fmt.Sprintf("/api/teams/%d/players/%d", madrid.ID, pedri.ID), nil, http.StatusNotFound, codePlayerNotFound, ""},
+		{"jugador desconocido", http.MethodPut, players + "/999", valid(nil), http.StatusNotFound, codePlayerNotFound, ""},
+		{"id de jugador inválido", http.MethodDelete, players + "/x", nil, http.StatusBadRequest, codeInvalidID, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := expectProblem(t, s.do(tt.method, tt.target, tt.body), tt.status, tt.code)
+			if tt.field != "" && (len(p.Errors) != 1 || p.Errors[0].Field != tt.field) {
+				t.Errorf("errores %+v, se esperaba el campo %s", p.Errors, tt.field)
+			}
+		})
+	}
+
+	// El mismo dorsal está libre en otra temporada
+	expectStatus(t, s.do(http.MethodPost, players, valid(map[string]any{"shirtNumber": 8, "season": "2025-26"})), http.StatusCreated)
+}
+
+func TestPlayerEventAttribution(t *testing.T) {
+	s := newTestServer(t)
+	barcelona, madrid, sevilla := s.team("Barcelona"), s.team("Real Madrid"), s.team("Sevilla")
+	m := s.match(barcelona, madrid, "2025-04-01")
+	pedri := s.player(barcelona, "Pedri", 8, "2024-25")
+	vinicius := s.player(madrid, "Vinícius", 7, "2024-25")
+	lastSeason := s.player(barcelona, "Ansu Fati", 10, "2023-24")
+	other := s.player(sevilla, "Navas", 16, "2024-25")
+	events := fmt.Sprintf("/api/matches/%d/events", m.ID)
+
+	rec := s.do(http.MethodPost, events, map[string]any{"type": eventGoal, "minute": 12, "playerId": pedri.ID})
+	expectStatus(t, rec, http.StatusCreated)
+	got := decode[MatchEventResponse](t, rec)
+	if got.Event.Team != sideHome || got.Event.Player != "Pedri" || got.Event.PlayerID != pedri.ID || got.Match.HomeScore != 1 {
+		t.Errorf("evento %+v y partido %+v, se esperaba el gol local de Pedri", got.Event, got.Match)
+	}
+	rec = s.do(http.MethodPatch, fmt.Sprintf("/api/matches/%d/yellowcards", m.ID), map[string]any{"team": sideAway, "playerId": vinicius.ID})
+	expectStatus(t, rec, http.StatusOK)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   map[string]any
+		code   string
+	}{
+		{"jugador desconocido", http.MethodPost, events, map[string]any{"type": eventGoal, "minute": 20, "playerId": 999}, codeUnknownPlayer},
+		{"jugador de otro equipo", http.MethodPost, events, map[string]any{"type": eventGoal, "minute": 20, "playerId": other.ID}, codePlayerNotInMatch},
+		{"jugador de otra temporada", http.MethodPost, events, map[string]any{"type": eventRedCard, "minute": 20, "playerId": lastSeason.ID}, codePlayerNotInMatch},
+		{"lado distinto al del jugador", http.MethodPatch, fmt.Sprintf("/api/matches/%d/goals", m.ID), map[string]any{"side": sideAway, "playerId": pedri.ID}, codePlayerNotInMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectProblem(t, s.do(tt.method, tt.target, tt.body), http.StatusUnprocessableEntity, tt.code)
+		})
+	}
+
+	stats := func(p Player) PlayerStats {
+		t.Helper()
+		rec := s.do(http.MethodGet, fmt.Sprintf("/api/teams/%d/players/%d", p.TeamID, p.ID), nil)
+		expectStatus(t, rec, http.StatusOK)
+		return decode[Player](t, rec).Stats
+	}
+	if got := stats(pedri); got != (PlayerStats{Goals: 1}) {
+		t.Errorf("estadísticas de Pedri %+v, se esperaba 1 gol", got)
+	}
+	if got := stats(vinicius); got != (PlayerStats{YellowCards: 1}) {
+		t.Errorf("estadísticas de Vinícius %+v, se esperaba 1 amarilla", got)
+	}
+
+	// Un gol anulado no cuenta en las estadísticas, pero el jugador sigue en la
+	// cronología y no se puede eliminar
+	rec = s.do(http.MethodPost, fmt.Sprintf("%s/%d/void", events, got.Event.ID), map[string]string{"reason": "fuera de juego"})
+	expectStatus(t, rec, http.StatusOK)
+	if got := stats(pedri); got != (PlayerStats{}) {
+		t.Errorf("estadísticas de Pedri %+v tras anular el gol, se esperaba cero", got)
+	}
+	expectProblem(t, s.do(http.MethodDelete, fmt.Sprintf("/api/teams/%d/players/%d", barcelona.ID, pedri.ID), nil), http.StatusConflict, codePlayerInUse)
+
+	// Renombrar al jugador actualiza los eventos atribuidos
+	rec = s.do(http.MethodPut, fmt.Sprintf("/api/teams/%d/players/%d", barcelona.ID, pedri.ID),
+		map[string]any{"name": "Pedro González", "shirtNumber": 8, "position": "midfielder", "season": "2024-25"})
+	expectStatus(t, rec, http.StatusOK)
+	timeline, err := s.store.ListMatchEvents(t.Context(), m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeline) != 2 || timeline[0].Player != "Pedro González" {
+		t.Errorf("cronología %+v, se esperaba el nombre nuevo en el gol", timeline)
+	}
+
+	expectStatus(t, s.do(http.MethodDelete, fmt.Sprintf("/api/teams/%d/players/%d", sevilla.ID, other.ID), nil), http.StatusNoContent)
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/puddle/v2"
+)
+
+// statusClientClosedRequest se usa (como en nginx) cuando el cliente cerró
+// la conexión antes de recibir la respuesta
+const statusClientClosedRequest = 499
+
+// problemContentType es el tipo de las respuestas de error (RFC 7807)
+const problemContentType = "application/problem+json"
+
+// Códigos de error estables de la API. Los clientes deben decidir según el
+// código y no según el texto, que puede cambiar.
+const (
+	codeMatchNotFound         = "MATCH_NOT_FOUND"
+	codeInvalidID             = "INVALID_ID"
+	codeInvalidDate           = "INVALID_DATE"
+	codeInvalidQuery          = "INVALID_QUERY"
+	codeValidationFailed      = "VALIDATION_FAILED"
+	codeMalformedBody         = "MALFORMED_BODY"
+	codeUnsupportedMediaType  = "UNSUPPORTED_MEDIA_TYPE"
+	codePatchTestFailed       = "PATCH_TEST_FAILED"
+	codePatchNotApplicable    = "PATCH_NOT_APPLICABLE"
+	codeInvalidPatchResult    = "INVALID_PATCH_RESULT"
+	codePreconditionFailed    = "PRECONDITION_FAILED"
+	codePreconditionRequired  = "PRECONDITION_REQUIRED"
+	codeInvalidIdempotencyKey = "INVALID_IDEMPOTENCY_KEY"
+	codeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	codeIdempotencyKeyInUse   = "IDEMPOTENCY_KEY_IN_USE"
+	codeBatchTooLarge         = "BATCH_TOO_LARGE"
+	codeBodyTooLarge          = "BODY_TOO_LARGE"
+	codeExtraTimeLimit        = "EXTRA_TIME_LIMIT"
+	codeEventNotFound         = "EVENT_NOT_FOUND"
+	codeEventAlreadyVoided    = "EVENT_ALREADY_VOIDED"
+	codeCounterBelowZero      = "COUNTER_BELOW_ZERO"
+	codeTeamNotFound          = "TEAM_NOT_FOUND"
+	codeUnknownTeam           = "UNKNOWN_TEAM"
+	codeTeamConflict          = "TEAM_CONFLICT"
+	codeTeamInUse             = "TEAM_IN_USE"
+	codePlayerNotFound        = "PLAYER_NOT_FOUND"
+	codeShirtNumberTaken      = "SHIRT_NUMBER_TAKEN"
+	codePlayerInUse           = "PLAYER_IN_USE"
+	codeUnknownPlayer         = "UNKNOWN_PLAYER"
+	codePlayerNotInMatch      = "PLAYER_NOT_IN_MATCH"
+	codeRouteNotFound         = "ROUTE_NOT_FOUND"
+	codeMethodNotAllowed      = "METHOD_NOT_ALLOWED"
+	codePoolUnavailable       = "POOL_NOT_AVAILABLE"
+	codeDatabaseTimeout       = "DATABASE_TIMEOUT"
+	codeDatabaseDown          = "DATABASE_UNAVAILABLE"
+	codeInternal              = "INTERNAL_ERROR"
+)
+
+// Códigos de los errores por campo
+const (
+	fieldRequired     = "REQUIRED"
+	fieldInvalidValue = "INVALID_VALUE"
+	fieldInvalidType  = "INVALID_TYPE"
+)
+
+// problemStatus define el estado HTTP de cada código. El título se toma del
+// catálogo de mensajes con la clave "problem.<código>".
+var problemStatus = map[string]int{
+	codeMatchNotFound:         http.StatusNotFound,
+	codeInvalidID:             http.StatusBadRequest,
+	codeInvalidDate:           http.StatusBadRequest,
+	codeInvalidQuery:          http.StatusBadRequest,
+	codeValidationFailed:      http.StatusBadRequest,
+	codeMalformedBody:         http.StatusBadRequest,
+	codeUnsupportedMediaType:  http.StatusUnsupportedMediaType,
+	codePatchTestFailed:       http.StatusConflict,
+	codePatchNotApplicable:    http.StatusUnprocessableEntity,
+	codeInvalidPatchResult:    http.StatusUnprocessableEntity,
+	codePreconditionFailed:    http.StatusPreconditionFailed,
+	codePreconditionRequired:  http.StatusPreconditionRequired,
+	codeInvalidIdempotencyKey: http.StatusBadRequest,
+	codeIdempotencyKeyReused:  http.StatusUnprocessableEntity,
+	codeIdempotencyKeyInUse:   http.StatusConflict,
+	codeBatchTooLarge:         http.StatusRequestEntityTooLarge,
+	codeBodyTooLarge:          http.StatusRequestEntityTooLarge,
+	codeExtraTimeLimit:        http.StatusConflict,
+	codeEventNotFound:         http.StatusNotFound,
+	codeEventAlreadyVoided:    http.StatusConflict,
+	codeCounterBelowZero:      http.StatusConflict,
+	codeTeamNotFound:          http.StatusNotFound,
+	codeUnknownTeam:           http.StatusUnprocessableEntity,
+	codeTeamConflict:          http.StatusConflict,
+	codeTeamInUse:             http.StatusConflict,
+	codePlayerNotFound:        http.StatusNotFound,
+	codeShirtNumberTaken:      http.StatusConflict,
+	codePlayerInUse:           http.StatusConflict,
+	codeUnknownPlayer:         http.StatusUnprocessableEntity,
+	codePlayerNotInMatch:      http.StatusUnprocessableEntity,
+	codeRouteNotFound:         http.StatusNotFound,
+	codeMethodNotAllowed:      http.StatusMethodNotAllowed,
+	codePoolUnavailable:       http.StatusServiceUnavailable,
+	codeDatabaseTimeout:       http.StatusGatewayTimeout,
+	codeDatabaseDown:          http.StatusServiceUnavailable,
+	codeInternal:              http.StatusInternalServerError,
+}
+
+// Problem es el cuerpo de todas las respuestas de error (RFC 7807)
+// @Description Error con formato application/problem+json
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"requestId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describe un campo del cuerpo o un parámetro inválido
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// problemTypeURI identifica el tipo de error, por ejemplo /problems/match-not-found
+func problemTypeURI(code string) string {
+	return "/problems/" + strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+}
+
+// respondProblem responde el error indicado por code. La instancia y el id de
+// la petición permiten encontrarla en los logs.
+func respondProblem(c *gin.Context, code, detail string, fields ...FieldError) {
+	status, ok := problemStatus[code]
+	if !ok {
+		code, status = codeInternal, problemStatus[codeInternal]
+	}
+	c.Header("Content-Type", problemContentType)
+	c.Abort()
+	c.IndentedJSON(status, Problem{
+		Type:      problemTypeURI(code),
+		Title:     tr(c, "problem."+code),
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      code,
+		RequestID: requestID(c),
+		Errors:    fields,
+	})
+}
+
+// parseMatchID lee el parámetro :id y responde INVALID_ID si no es un número
+func parseMatchID(c *gin.Context) (int, bool) {
+	return parsePathID(c, "id")
+}
+
+// parsePathID lee el parámetro numérico name de la ruta o responde INVALID_ID
+func parsePathID(c *gin.Context, name string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil {
+		respondProblem(c, codeInvalidID, tr(c, "detail.invalid_id"),
+			FieldError{Field: name, Code: fieldInvalidType, Message: tr(c, "field.integer")})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondInvalidDate responde INVALID_DATE cuando matchDate no es YYYY-MM-DD
+func respondInvalidDate(c *gin.Context) {
+	respondProblem(c, codeInvalidDate, tr(c, "detail.invalid_date"),
+		FieldError{Field: "matchDate", Code: codeInvalidDate, Message: tr(c, "field.date_format")})
+}
+
+// respondBindError traduce los errores de ShouldBindJSON a errores por campo
+func respondBindError(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &validationErrs):
+		var fields []FieldError
+		for _, fe := range validationErrs {
+			fields = append(fields, validationFieldError(c, fe))
+		}
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"), fields...)
+	case errors.As(err, &typeErr):
+		respondProblem(c, codeMalformedBody, tr(c, "detail.invalid_type"),
+			FieldError{Field: typeErr.Field, Code: fieldInvalidType, Message: tr(c, "field.type", typeErr.Type.String())})
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		respondProblem(c, codeMalformedBody, tr(c, "detail.invalid_json"))
+	default:
+		c.Error(err)
+		respondProblem(c, codeMalformedBody, tr(c, "detail.unreadable_body"))
+	}
+}
+
+// validationFieldError traduce una regla del validador de gin con la clave
+// "validation.<regla>" o, si no existe, con "validation.default"
+func validationFieldError(c *gin.Context, fe validator.FieldError) FieldError {
+	code := fieldInvalidValue
+	if fe.Tag() == "required" {
+		code = fieldRequired
+	}
+	key := "validation." + fe.Tag()
+	var message string
+	switch {
+	case !hasTranslation(key):
+		message = tr(c, "validation.default", fe.Tag())
+	case fe.Param() == "":
+		message = tr(c, key)
+	default:
+		message = tr(c, key, fe.Param())
+	}
+	return FieldError{Field: fe.Field(), Code: code, Message: message}
+}
+
+// useJSONFieldNames hace que el validador reporte los campos con su nombre
+// JSON (homeTeam) en lugar del nombre en Go (HomeTeam)
+func useJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return f.Name
+		}
+		return name
+	})
+}
+
+// respondStoreError traduce un error del almacenamiento a una respuesta HTTP.
+// Solo se informa al cliente la categoría del error; la causa queda en el log.
+func respondStoreError(c *gin.Context, err error) {
+	ctxErr := c.Request.Context().Err()
+
+	if errors.Is(err, ErrMatchNotFound) {
+		respondProblem(c, codeMatchNotFound, tr(c, "detail.match_not_found", c.Param("id")))
+		return
+	}
+	if errors.Is(err, ErrVersionMismatch) {
+		respondProblem(c, codePreconditionFailed, tr(c, "detail.version_changed"))
+		return
+	}
+	if errors.Is(err, ErrExtraTimeLimit) {
+		respondProblem(c, codeExtraTimeLimit, tr(c, "detail.extra_time_limit", maxExtraTime))
+		return
+	}
+	if errors.Is(err, ErrEventNotFound) {
+		respondProblem(c, codeEventNotFound, tr(c, "detail.event_not_found", c.Param("eventId"), c.Param("id")))
+		return
+	}
+	if errors.Is(err, ErrEventAlreadyVoided) {
+		respondProblem(c, codeEventAlreadyVoided, tr(c, "detail.event_already_voided"))
+		return
+	}
+	if errors.Is(err, ErrCounterBelowZero) {
+		respondProblem(c, codeCounterBelowZero, tr(c, "detail.counter_below_zero"))
+		return
+	}
+	if errors.Is(err, ErrTeamNotFound) {
+		respondProblem(c, codeTeamNotFound, tr(c, "detail.team_not_found", c.Param("id")))
+		return
+	}
+	if errors.Is(err, ErrUnknownTeam) {
+		respondProblem(c, codeUnknownTeam, tr(c, "detail.unknown_team"))
+		return
+	}
+	if errors.Is(err, ErrTeamConflict) {
+		respondProblem(c, codeTeamConflict, tr(c, "detail.team_conflict"))
+		return
+	}
+	if errors.Is(err, ErrTeamInUse) {
+		respondProblem(c, codeTeamInUse, tr(c, "detail.team_in_use"))
+		return
+	}
+	if errors.Is(err, ErrPlayerNotFound) {
+		respondProblem(c, codePlayerNotFound, tr(c, "detail.player_not_found", c.Param("playerId"), c.Param("id")))
+		return
+	}
+	if errors.Is(err, ErrShirtNumberTaken) {
+		respondProblem(c, codeShirtNumberTaken, tr(c, "detail.shirt_number_taken"))
+		return
+	}
+	if errors.Is(err, ErrPlayerInUse) {
+		respondProblem(c, codePlayerInUse, tr(c, "detail.player_in_use"))
+		return
+	}
+	if errors.Is(err, ErrUnknownPlayer) {
+		respondProblem(c, codeUnknownPlayer, tr(c, "detail.unknown_player"))
+		return
+	}
+	if errors.Is(err, ErrPlayerNotInMatch) {
+		respondProblem(c, codePlayerNotInMatch, tr(c, "detail.player_not_in_match"))
+		return
+	}
+
+	// La causa queda en el log de la petición (ver accessLog)
+	c.Error(err)
+	if errors.Is(ctxErr, context.Canceled) {
+		// El cliente ya no espera la respuesta
+		c.AbortWithStatus(statusClientClosedRequest)
+		return
+	}
+	respondProblem(c, storeFailureCode(ctxErr, err), "")
+}
+
+// storeFailureCode clasifica un error inesperado del almacenamiento
+func storeFailureCode(ctxErr, err error) string {
+	var connectErr *pgconn.ConnectError
+	switch {
+	case errors.Is(ctxErr, context.DeadlineExceeded), errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		return codeDatabaseTimeout
+	case errors.As(err, &connectErr), errors.Is(err, puddle.ErrClosedPool):
+		return codeDatabaseDown
+	default:
+		return codeInternal
+	}
+}
+
+// routeNotFound y methodNotAllowed responden con problem+json las rutas
+// que gin no encuentra
+func routeNotFound(c *gin.Context) {
+	respondProblem(c, codeRouteNotFound, tr(c, "detail.route_not_found", c.Request.Method, c.Request.URL.Path))
+}
+
+func methodNotAllowed(c *gin.Context) {
+	respondProblem(c, codeMethodNotAllowed, tr(c, "detail.method_not_allowed", c.Request.URL.Path, c.Request.Method))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestProblemResponses(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	s.match(home, away, "2025-04-01")
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		status int
+		code   string
+		fields []string
+	}{
+		{"ruta inexistente", http.MethodGet, "/api/partidos", nil, http.StatusNotFound, codeRouteNotFound, nil},
+		{"método no permitido", http.MethodPost, "/api/matches/1", nil, http.StatusMethodNotAllowed, codeMethodNotAllowed, nil},
+		{"id no numérico", http.MethodGet, "/api/matches/abc", nil, http.StatusBadRequest, codeInvalidID, []string{"id"}},
+		{"partido inexistente", http.MethodGet, "/api/matches/999", nil, http.StatusNotFound, codeMatchNotFound, nil},
+		{"JSON inválido", http.MethodPost, "/api/matches", `{"homeTeam":`, http.StatusBadRequest, codeMalformedBody, nil},
+		{"tipo inválido", http.MethodPost, "/api/matches", `{"homeTeamId":"uno"}`, http.StatusBadRequest, codeMalformedBody, []string{"homeTeamId"}},
+		{"campo obligatorio", http.MethodPost, "/api/matches", map[string]any{"homeTeamId": home.ID, "awayTeamId": away.ID}, http.StatusBadRequest, codeValidationFailed, []string{"matchDate"}},
+		{"fecha inválida", http.MethodPost, "/api/matches", map[string]any{"homeTeamId": home.ID, "awayTeamId": away.ID, "matchDate": "01/04/2025"}, http.StatusBadRequest, codeInvalidDate, []string{"matchDate"}},
+		{"pool sin PostgreSQL", http.MethodGet, "/api/admin/pool", nil, http.StatusServiceUnavailable, codePoolUnavailable, nil},
+		{"equipo desconocido", http.MethodPost, "/api/matches", map[string]any{"homeTeamId": home.ID, "awayTeamId": 999, "matchDate": "2025-04-01"}, http.StatusUnprocessableEntity, codeUnknownTeam, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(tt.method, tt.target, tt.body, "X-Request-ID", "req-1")
+			p := expectProblem(t, rec, tt.status, tt.code)
+			if p.Type != problemTypeURI(tt.code) || p.Title == "" || p.Title == "problem."+tt.code {
+				t.Errorf("tipo o título inválidos: %+v", p)
+			}
+			if p.RequestID != "req-1" {
+				t.Errorf("requestId %q, se esperaba req-1", p.RequestID)
+			}
+			var fields []string
+			for _, fe := range p.Errors {
+				fields = append(fields, fe.Field)
+			}
+			if fmt.Sprint(fields) != fmt.Sprint(tt.fields) {
+				t.Errorf("campos %v, se esperaba %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestProblemTitleLanguage(t *testing.T) {
+	s := newTestServer(t)
+	for lang, title := range map[string]string{"es": catalogs["es"]["problem."+codeMatchNotFound], "en": catalogs["en"]["problem."+codeMatchNotFound]} {
+		t.Run(lang, func(t *testing.T) {
+			p := expectProblem(t, s.do(http.MethodGet, "/api/matches/999", nil, "Accept-Language", lang), http.StatusNotFound, codeMatchNotFound)
+			if title == "" || p.Title != title {
+				t.Errorf("título %q, se esperaba %q", p.Title, title)
+			}
+		})
+	}
+}
+
+func TestRespondStoreError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{ErrMatchNotFound, codeMatchNotFound},
+		{fmt.Errorf("envuelto: %w", ErrMatchNotFound), codeMatchNotFound},
+		{ErrVersionMismatch, codePreconditionFailed},
+		{ErrExtraTimeLimit, codeExtraTimeLimit},
+		{ErrEventNotFound, codeEventNotFound},
+		{ErrEventAlreadyVoided, codeEventAlreadyVoided},
+		{ErrCounterBelowZero, codeCounterBelowZero},
+		{ErrTeamNotFound, codeTeamNotFound},
+		{ErrUnknownTeam, codeUnknownTeam},
+		{ErrTeamConflict, codeTeamConflict},
+		{ErrTeamInUse, codeTeamInUse},
+		{ErrPlayerNotFound, codePlayerNotFound},
+		{ErrShirtNumberTaken, codeShirtNumberTaken},
+		{ErrPlayerInUse, codePlayerInUse},
+		{ErrUnknownPlayer, codeUnknownPlayer},
+		{ErrPlayerNotInMatch, codePlayerNotInMatch},
+		{context.DeadlineExceeded, codeDatabaseTimeout},
+		{errors.New("fallo inesperado"), codeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/matches/1", nil)
+			respondStoreError(c, tt.err)
+			expectProblem(t, rec, problemStatus[tt.code], tt.code)
+		})
+	}
+}
+
+func TestProblemCodesHaveTitles(t *testing.T) {
+	for code := range problemStatus {
+		if !hasTranslation("problem." + code) {
+			t.Errorf("falta la traducción problem.%s", code)
+		}
+	}
+}
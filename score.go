@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+)
+
+// Equipos que pueden anotar un gol
+const (
+	sideHome = "home"
+	sideAway = "away"
+)
+
+// Resultados derivados de los marcadores
+const (
+	resultHome = "home" // gana el local
+	resultDraw = "draw"
+	resultAway = "away" // gana el visitante
+)
+
+// goalRequest es el cuerpo de PATCH /api/matches/{id}/goals
+type goalRequest struct {
+	Side string `json:"side" binding:"required,oneof=home away" enums:"home,away"`
+	eventDetails
+}
+
+// unattributedGoals son los goles registrados antes de separar los
+// marcadores por equipo, que no se pueden asignar a ninguno
+func (m Match) unattributedGoals() int {
+	return m.Goals - m.HomeScore - m.AwayScore
+}
+
+// result deriva el resultado de los marcadores; es vacío si el partido tiene
+// goles sin equipo porque no se sabe quién va ganando
+func (m Match) result() string {
+	switch {
+	case m.unattributedGoals() != 0:
+		return ""
+	case m.HomeScore > m.AwayScore:
+		return resultHome
+	case m.HomeScore < m.AwayScore:
+		return resultAway
+	default:
+		return resultDraw
+	}
+}
+
+// MarshalJSON completa Result, que nunca se almacena
+func (m Match) MarshalJSON() ([]byte, error) {
+	type plain Match
+	m.Result = m.result()
+	return json.Marshal(plain(m))
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestEventCounters(t *testing.T) {
+	tests := []struct {
+		eventType string
+		team      string
+		want      []string
+	}{
+		{eventGoal, sideHome, []string{"goals", "homeScore"}},
+		{eventGoal, sideAway, []string{"goals", "awayScore"}},
+		{eventGoal, "", []string{"goals"}},
+		{eventYellowCard, sideHome, []string{"yellowCards"}},
+		{eventRedCard, "", []string{"redCards"}},
+		{eventExtraTime, "", []string{"extraTime"}},
+		{eventSubstitution, sideAway, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType+"/"+tt.team, func(t *testing.T) {
+			var got []string
+			for _, c := range eventCounters(&Match{}, tt.eventType, tt.team) {
+				got = append(got, c.name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("contadores %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterGoalScore(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+	m := s.match(home, away, "2025-04-01")
+	target := fmt.Sprintf("/api/matches/%d/goals", m.ID)
+
+	steps := []struct {
+		side                 string
+		homeScore, awayScore int
+		result               string
+	}{
+		{sideAway, 0, 1, resultAway},
+		{sideHome, 1, 1, resultDraw},
+		{sideHome, 2, 1, resultHome},
+	}
+	for _, step := range steps {
+		rec := s.do(http.MethodPatch, target, map[string]string{"side": step.side})
+		expectStatus(t, rec, http.StatusOK)
+		got := decode[struct {
+			HomeScore int        `json:"homeScore"`
+			AwayScore int        `json:"awayScore"`
+			Result    string     `json:"result"`
+			Event     MatchEvent `json:"event"`
+		}](t, rec)
+		if got.HomeScore != step.homeScore || got.AwayScore != step.awayScore || got.Result != step.result || got.Event.Team != step.side {
+			t.Fatalf("tras un gol de %s: %+v, se esperaba %d-%d %s", step.side, got, step.homeScore, step.awayScore, step.result)
+		}
+	}
+
+	match := decode[Match](t, s.do(http.MethodGet, fmt.Sprintf("/api/matches/%d", m.ID), nil))
+	if match.Goals != 3 || match.HomeScore != 2 || match.AwayScore != 1 || match.Result != resultHome {
+		t.Errorf("partido %+v, se esperaba 3 goles, 2-1 y result home", match)
+	}
+
+	for _, body := range []any{map[string]string{}, map[string]string{"side": "both"}} {
+		expectProblem(t, s.do(http.MethodPatch, target, body), http.StatusBadRequest, codeValidationFailed)
+	}
+}
+
+func TestMatchResultWithUnattributedGoals(t *testing.T) {
+	tests := []struct {
+		goals, homeScore, awayScore int
+		want                        string
+	}{
+		{0, 0, 0, resultDraw},
+		{2, 1, 1, resultDraw},
+		{3, 1, 1, ""},
+		{1, 1, 0, resultHome},
+		{1, 0, 0, ""},
+	}
+	for _, tt := range tests {
+		m := Match{Goals: tt.goals, HomeScore: tt.homeScore, AwayScore: tt.awayScore}
+		if got := m.result(); got != tt.want {
+			t.Errorf("result de %d goles y %d-%d es %q, se esperaba %q", tt.goals, tt.homeScore, tt.awayScore, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"html"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/unicode/norm"
+)
+
+// searchMinScore es la relevancia mínima para incluir un partido en la búsqueda
+const searchMinScore = 0.4
+
+// teamAliases asocia apodos y nombres alternativos, ya normalizados, con el
+// nombre con el que se suele registrar el equipo
+var teamAliases = map[string]string{
+	"barca":              "barcelona",
+	"fc barcelona":       "barcelona",
+	"atleti":             "atletico",
+	"atletico de madrid": "atletico",
+	"atletico madrid":    "atletico",
+	"real madrid cf":     "real madrid",
+	"la real":            "real sociedad",
+	"athletic":           "athletic club",
+	"athletic bilbao":    "athletic club",
+	"bilbao":             "athletic club",
+	"betis":              "real betis",
+	"celta de vigo":      "celta",
+	"rayo":               "rayo vallecano",
+	"alaves":             "deportivo alaves",
+}
+
+// foldRune elimina el acento de r: "é" se convierte en "e" y "ñ" en "n".
+// Siempre retorna una sola runa para que las posiciones coincidan con el texto original.
+func foldRune(r rune) rune {
+	if r < unicode.MaxASCII {
+		return unicode.ToLower(r)
+	}
+	for _, d := range norm.NFD.String(string(r)) {
+		return unicode.ToLower(d)
+	}
+	return r
+}
+
+// normalizeTeam pasa a minúsculas, elimina acentos y colapsa los espacios,
+// igual que immutable_unaccent(lower(...)) en PostgreSQL
+func normalizeTeam(s string) string {
+	return strings.Join(strings.Fields(strings.Map(foldRune, s)), " ")
+}
+
+// expandSearchTerms retorna el texto buscado normalizado más el nombre al que
+// apunta si es un alias conocido
+func expandSearchTerms(q string) []string {
+	term := normalizeTeam(q)
+	terms := []string{term}
+	if canonical, ok := teamAliases[term]; ok && canonical != term {
+		terms = append(terms, canonical)
+	}
+	return terms
+}
+
+// teamScore replica en Go la relevancia que calcula PostgreSQL: 1 si alguna
+// palabra del equipo empieza con el término y si no la similitud por trigramas
+func teamScore(term, team string) float64 {
+	if strings.HasPrefix(team, term) || strings.Contains(team, " "+term) {
+		return 1
+	}
+	return max(wordSimilarity(term, team), wordSimilarity(team, term))
+}
+
+// wordSimilarity aproxima word_similarity de pg_trgm: la fracción de los
+// trigramas de a que aparecen en b
+func wordSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta))
+}
+
+// trigrams separa s en palabras y las rellena como pg_trgm ("  ab", " abc", "bc ")
+func trigrams(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// highlight envuelve en <mark> las palabras del equipo que empiezan con alguna
+// palabra de los términos, sin distinguir acentos. El resto del texto se escapa.
+func highlight(team string, terms []string) string {
+	var words []string
+	for _, term := range terms {
+		for _, w := range strings.Fields(term) {
+			if len([]rune(w)) >= 2 && !slices.Contains(words, w) {
+				words = append(words, w)
+			}
+		}
+	}
+	// Las palabras más largas primero para marcar "real madrid" antes que "real"
+	slices.SortFunc(words, func(a, b string) int { return len(b) - len(a) })
+
+	original := []rune(team)
+	folded := []rune(strings.Map(foldRune, team))
+	marked := make([]bool, len(original))
+	for _, w := range words {
+		wr := []rune(w)
+		for i := 0; i+len(wr) <= len(folded); i++ {
+			atWordStart := i == 0 || !unicode.IsLetter(folded[i-1]) && !unicode.IsDigit(folded[i-1])
+			if atWordStart && string(folded[i:i+len(wr)]) == w {
+				for j := i; j < i+len(wr); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, r := range original {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			b.WriteString("<mark>")
+		}
+		b.WriteString(html.EscapeString(string(r)))
+		if marked[i] && (i == len(original)-1 || !marked[i+1]) {
+			b.WriteString("</mark>")
+		}
+	}
+	return b.String()
+}
+
+// SearchResult es un partido encontrado por la búsqueda
+// @Description Partido encontrado con su relevancia y los equipos resaltados
+type SearchResult struct {
+	Match     Match           `json:"match"`
+	Score     float64         `json:"score"`
+	Highlight SearchHighlight `json:"highlight"`
+}
+
+// SearchHighlight contiene los nombres de los equipos con las coincidencias en <mark>
+type SearchHighlight struct {
+	HomeTeam string `json:"homeTeam"`
+	AwayTeam string `json:"awayTeam"`
+}
+
+// SearchResponse es la respuesta de la búsqueda de partidos
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Terms   []string       `json:"terms"`
+	Results []SearchResult `json:"results"`
+}
+
+// searchMatches godoc
+// @Summary Buscar partidos por equipo
+// @Description Busca partidos por el nombre del equipo local o visitante sin distinguir acentos ni mayúsculas.
+// @Description Reconoce apodos como "Barça" o "Atleti", admite prefijos para autocompletar y ordena por relevancia.
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param q query string true "Texto a buscar (al menos 2 caracteres)"
+// @Param limit query int false "Cantidad máxima de resultados"
+// @Success 200 {object} SearchResponse
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/search [get]
+func (a *app) searchMatches(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	if len([]rune(normalizeTeam(q))) < 2 {
+		respondProblem(c, codeInvalidQuery, tr(c, "detail.search_min_length"),
+			FieldError{Field: "q", Code: fieldInvalidValue, Message: tr(c, "field.min_length", 2)})
+		return
+	}
+	limit := a.pagination.DefaultLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > a.pagination.MaxLimit {
+			fe := limitError(c, a.pagination)
+			respondProblem(c, codeInvalidQuery, fe.Error(), *fe)
+			return
+		}
+		limit = n
+	}
+
+	terms := expandSearchTerms(q)
+	ctx := c.Request.Context()
+	hits, err := a.store.SearchMatches(ctx, MatchSearch{Terms: terms, MinScore: searchMinScore, Limit: limit})
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+
+	resp := SearchResponse{Query: q, Terms: terms, Results: []SearchResult{}}
+	for _, hit := range hits {
+		resp.Results = append(resp.Results, SearchResult{
+			Match: hit.Match,
+			Score: hit.Score,
+			Highlight: SearchHighlight{
+				HomeTeam: highlight(hit.Match.HomeTeam, terms),
+				AwayTeam: highlight(hit.Match.AwayTeam, terms),
+			},
+		})
+	}
+	c.IndentedJSON(http.StatusOK, resp)
+}
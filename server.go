@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// serverSettings define la dirección y los tiempos del servidor HTTP
+type serverSettings struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// ShutdownGrace es el tiempo que se espera a las peticiones en curso al detenerse
+	ShutdownGrace time.Duration
+}
+
+// runServer atiende peticiones hasta recibir SIGINT o SIGTERM. Entonces deja
+// de aceptar conexiones, espera a las peticiones en curso durante el periodo
+// de gracia y finalmente cierra el almacenamiento. Los jobs se ejecutan en
+// segundo plano mientras tanto y terminan, al cancelarse su contexto, antes de
+// cerrar el almacenamiento.
+func runServer(s serverSettings, handler http.Handler, store MatchStore, jobs ...func(ctx context.Context)) error {
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: s.ReadHeaderTimeout,
+		ReadTimeout:       s.ReadTimeout,
+		WriteTimeout:      s.WriteTimeout,
+		IdleTimeout:       s.IdleTimeout,
+	}
+	defer func() {
+		store.Close()
+		slog.Info("almacenamiento cerrado")
+	}()
+
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for _, job := range jobs {
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			job(ctx)
+		}()
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("servidor escuchando", "addr", s.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("el servidor se detuvo inesperadamente: %w", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	slog.Info("señal recibida, dejando de aceptar conexiones", "grace_period", s.ShutdownGrace.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownGrace)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("las peticiones en curso no terminaron a tiempo", "error", err)
+		srv.Close()
+	} else {
+		slog.Info("peticiones en curso completadas")
+	}
+
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
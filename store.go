@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrMatchNotFound se retorna cuando el partido solicitado no existe
+var ErrMatchNotFound = errors.New("partido no encontrado")
+
+// ErrVersionMismatch se retorna cuando el partido cambió desde la versión
+// que el cliente leyó
+var ErrVersionMismatch = errors.New("la versión del partido no coincide")
+
+// ErrExtraTimeLimit se retorna al agregar tiempo extra a un partido que ya
+// llegó a maxExtraTime
+var ErrExtraTimeLimit = errors.New("el tiempo extra llegó al máximo")
+
+// ErrEventNotFound se retorna cuando el evento no existe en el partido
+var ErrEventNotFound = errors.New("evento no encontrado")
+
+// ErrEventAlreadyVoided se retorna al anular un evento ya anulado
+var ErrEventAlreadyVoided = errors.New("el evento ya fue anulado")
+
+// ErrCounterBelowZero se retorna cuando anular el evento dejaría un
+// contador del partido en negativo
+var ErrCounterBelowZero = errors.New("el contador no puede ser negativo")
+
+// ErrTeamNotFound se retorna cuando el equipo solicitado no existe
+var ErrTeamNotFound = errors.New("equipo no encontrado")
+
+// ErrUnknownTeam se retorna al guardar un partido con un equipo que no existe
+var ErrUnknownTeam = errors.New("el equipo del partido no existe")
+
+// ErrTeamConflict se retorna cuando ya existe otro equipo con el mismo nombre
+// o el mismo código
+var ErrTeamConflict = errors.New("ya existe un equipo con ese nombre o código")
+
+// ErrTeamInUse se retorna al eliminar un equipo que juega algún partido
+var ErrTeamInUse = errors.New("el equipo tiene partidos")
+
+// ErrPlayerNotFound se retorna cuando el jugador no existe en el equipo
+var ErrPlayerNotFound = errors.New("jugador no encontrado")
+
+// ErrShirtNumberTaken se retorna cuando otro jugador del equipo usa el mismo
+// dorsal en la temporada
+var ErrShirtNumberTaken = errors.New("el dorsal ya está asignado")
+
+// ErrPlayerInUse se retorna al eliminar un jugador con eventos en algún partido
+var ErrPlayerInUse = errors.New("el jugador tiene eventos")
+
+// ErrUnknownPlayer se retorna al registrar un evento con un jugador que no existe
+var ErrUnknownPlayer = errors.New("el jugador del evento no existe")
+
+// ErrPlayerNotInMatch se retorna al registrar un evento con un jugador que no
+// está en la plantilla de ninguno de los equipos del partido en su temporada,
+// o que no es del equipo indicado
+var ErrPlayerNotInMatch = errors.New("el jugador no juega en el partido")
+
+// maxExtraTime es el tope de minutos de tiempo extra de un partido
+const maxExtraTime = 30
+
+// MatchInput contiene los datos editables de un partido. El almacenamiento
+// completa los nombres de los equipos.
+type MatchInput struct {
+	HomeTeamID int
+	AwayTeamID int
+	MatchDate  time.Time
+	// Kickoff es la hora de inicio. Al actualizar, nil conserva la anterior
+	// si la fecha no cambia y la borra si cambia.
+	Kickoff *time.Time
+	Version int // versión esperada al actualizar; 0 no la verifica
+}
+
+// Criterios de orden del listado de partidos
+const (
+	sortByID   = "id"
+	sortByDate = "date"
+)
+
+// MatchQuery filtra, ordena y pagina el listado de partidos. Los valores
+// cero no filtran. After y Offset no se usan juntos.
+type MatchQuery struct {
+	Team     string // local o visitante; contiene el texto sin distinguir mayúsculas
+	TeamID   int    // local o visitante
+	HomeTeam string
+	AwayTeam string
+	From     time.Time // fecha mínima, inclusive
+	To       time.Time // fecha máxima, inclusive
+	Sort     string    // sortByID o sortByDate; el id desempata
+	Desc     bool
+	Limit    int
+	Offset   int
+	After    *MatchCursor
+}
+
+// MatchCursor es la posición del último partido de una página; el listado
+// continúa con los partidos que le siguen según el orden de la consulta
+type MatchCursor struct {
+	ID        int
+	MatchDate time.Time
+}
+
+// MatchPage es una página del listado. Total cuenta todos los partidos que
+// cumplen los filtros, sin importar la paginación.
+type MatchPage struct {
+	Matches []Match
+	Total   int
+}
+
+// MatchSearch busca partidos por nombre de equipo. Terms ya vienen
+// normalizados con normalizeTeam; un partido puntúa con el mejor término.
+type MatchSearch struct {
+	Terms    []string
+	MinScore float64
+	Limit    int
+}
+
+// SearchHit es un partido encontrado con su relevancia entre 0 y 1
+type SearchHit struct {
+	Match Match
+	Score float64
+}
+
+// IdempotencyRecord es la respuesta guardada para una Idempotency-Key.
+// Status es cero mientras la petición original está en curso.
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string // método, ruta y cuerpo de la petición original
+	Status      int
+	Header      http.Header
+	Body        []byte
+	CreatedAt   time.Time
+}
+
+// MatchStore abstrae el almacenamiento de partidos para que los handlers
+// no dependan de una base de datos concreta
+type MatchStore interface {
+	ListMatches(ctx context.Context, q MatchQuery) (MatchPage, error)
+	GetMatch(ctx context.Context, id int) (Match, error)
+	// CreateMatch, CreateMatches, UpdateMatch, ReplaceMatch y CorrectMatch retornan
+	// ErrUnknownTeam si alguno de los equipos no existe
+	CreateMatch(ctx context.Context, in MatchInput) (Match, error)
+	// CreateMatches crea todos los partidos o ninguno
+	CreateMatches(ctx context.Context, in []MatchInput) ([]Match, error)
+	// Las escrituras incrementan la versión del partido. UpdateMatch,
+	// ReplaceMatch, CorrectMatch y DeleteMatch retornan ErrVersionMismatch si se indica una
+	// versión distinta de cero que ya no es la actual.
+	UpdateMatch(ctx context.Context, id int, in MatchInput) (Match, error)
+	// ReplaceMatch guarda todos los campos editables de m, incluidos los
+	// contadores; m.Version es la versión esperada
+	ReplaceMatch(ctx context.Context, m Match) (Match, error)
+	// CorrectMatch es como ReplaceMatch pero registra cada cambio de un
+	// contador en la cronología en la misma operación: lo que aumenta se
+	// agrega como eventos sin minuto y lo que disminuye anula los últimos
+	// eventos vigentes con reason (ver counterCorrections). Los contadores
+	// anteriores a la cronología se descuentan con eventos ya anulados.
+	CorrectMatch(ctx context.Context, m Match, reason string) (Match, error)
+	DeleteMatch(ctx context.Context, id, version int) error
+	// SearchMatches ordena por relevancia, luego por fecha descendente
+	SearchMatches(ctx context.Context, q MatchSearch) ([]SearchHit, error)
+
+	// ListTeams retorna todos los equipos ordenados por nombre
+	ListTeams(ctx context.Context) ([]Team, error)
+	GetTeam(ctx context.Context, id int) (Team, error)
+	// FindTeam retorna el equipo cuyo nombre, nombre corto o código coincide,
+	// normalizado con normalizeTeam, con alguno de names; los primeros tienen
+	// preferencia. Retorna ErrTeamNotFound si ninguno coincide.
+	FindTeam(ctx context.Context, names []string) (Team, error)
+	// CreateTeam y UpdateTeam retornan ErrTeamConflict si otro equipo tiene
+	// el mismo nombre o código. Renombrar un equipo actualiza el nombre en
+	// sus partidos y aumenta su versión.
+	CreateTeam(ctx context.Context, in TeamInput) (Team, error)
+	UpdateTeam(ctx context.Context, id int, in TeamInput) (Team, error)
+	// DeleteTeam retorna ErrTeamInUse si el equipo juega algún partido.
+	// Elimina también sus jugadores.
+	DeleteTeam(ctx context.Context, id int) error
+
+	// ListPlayers retorna la plantilla del equipo en la temporada ordenada
+	// por dorsal, o ErrTeamNotFound si el equipo no existe
+	ListPlayers(ctx context.Context, teamID int, season string) ([]Player, error)
+	// GetPlayer retorna ErrPlayerNotFound si el jugador no es del equipo
+	GetPlayer(ctx context.Context, teamID, id int) (Player, error)
+	// CreatePlayer y UpdatePlayer retornan ErrShirtNumberTaken si otro
+	// jugador del equipo tiene el dorsal en la temporada. Renombrar un
+	// jugador actualiza el nombre en sus eventos.
+	CreatePlayer(ctx context.Context, in PlayerInput) (Player, error)
+	UpdatePlayer(ctx context.Context, id int, in PlayerInput) (Player, error)
+	// DeletePlayer retorna ErrPlayerInUse si el jugador tiene eventos
+	DeletePlayer(ctx context.Context, teamID, id int) error
+
+	// AddMatchEvent agrega un evento a la cronología del partido y actualiza
+	// el contador correspondiente en la misma operación, por lo que los
+	// contadores y la cronología no pueden diferir. Retorna el evento y el
+	// partido actualizado. Un evento extra_time con el tiempo extra en
+	// maxExtraTime retorna ErrExtraTimeLimit sin agregarse. Con PlayerID el
+	// equipo del evento es el del jugador (ver playerSide) y retorna
+	// ErrUnknownPlayer o ErrPlayerNotInMatch si no corresponde.
+	AddMatchEvent(ctx context.Context, in MatchEventInput) (MatchEvent, Match, error)
+	// ListMatchEvents retorna los eventos del partido en el orden en que se
+	// registraron, incluidos los anulados
+	ListMatchEvents(ctx context.Context, matchID int) ([]MatchEvent, error)
+	// VoidMatchEvent anula un evento y descuenta su contador en la misma
+	// operación; el evento sigue en la cronología con la fecha y el motivo.
+	// Retorna ErrEventNotFound, ErrEventAlreadyVoided o, si algún contador
+	// quedaría negativo, ErrCounterBelowZero sin cambiar nada. Sin EventID
+	// anula el último evento vigente de Type y, si se indica, de Team; un gol
+	// sin Team descuenta solo goals y requiere que haya goles sin equipo.
+	VoidMatchEvent(ctx context.Context, v MatchEventVoid) (MatchEvent, Match, error)
+
+	// ReserveIdempotencyKey registra rec si su clave no existe o venció antes
+	// de since y retorna true. Si la clave ya está registrada retorna el
+	// registro existente y false.
+	ReserveIdempotencyKey(ctx context.Context, rec IdempotencyRecord, since time.Time) (IdempotencyRecord, bool, error)
+	// CompleteIdempotencyKey guarda la respuesta de una clave reservada
+	CompleteIdempotencyKey(ctx context.Context, rec IdempotencyRecord) error
+	// ReleaseIdempotencyKey elimina una clave que sigue en curso para que la
+	// petición se pueda reintentar
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	// PurgeIdempotencyKeys elimina las claves registradas antes de before y
+	// retorna cuántas eliminó
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error)
+
+	// Ping verifica que el almacenamiento pueda atender consultas
+	Ping(ctx context.Context) error
+	// SchemaVersion retorna la versión del esquema aplicada en el almacenamiento
+	SchemaVersion(ctx context.Context) (int, error)
+	Close()
+}
+
+// Estados de un partido, derivados de su fecha
+const (
+	statusScheduled = "scheduled"
+	statusLive      = "live"
+	statusFinished  = "finished"
+)
+
+// matchToday retorna el día de now como se guardan las fechas de los
+// partidos: sin hora y en UTC. matchStatus y statusDateRange lo usan para que
+// el estado de un partido y el filtro por estado coincidan.
+func matchToday(now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// matchStatus deriva el estado de un partido: programado antes del día del
+// partido, en juego ese día y finalizado después
+func matchStatus(date, now time.Time) string {
+	day := date.Format(time.DateOnly)
+	today := matchToday(now).Format(time.DateOnly)
+	switch {
+	case day > today:
+		return statusScheduled
+	case day == today:
+		return statusLive
+	default:
+		return statusFinished
+	}
+}
@@ -0,0 +1,1009 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// storeCheck es una verificación de la batería de conformidad de MatchStore
+type storeCheck struct {
+	name string
+	fn   func(ctx context.Context, s MatchStore) error
+}
+
+// storeChecks define el comportamiento que toda implementación de MatchStore
+// debe cumplir. Cada verificación crea y elimina sus propios partidos y
+// equipos, por lo que puede ejecutarse contra una base de datos con datos
+// existentes.
+var storeChecks = []storeCheck{
+	{"crear y obtener un partido", checkCreateAndGet},
+	{"partido inexistente", checkNotFound},
+	{"crear partidos en lote", checkCreateBatch},
+	{"actualizar un partido", checkUpdate},
+	{"hora de inicio", checkKickoff},
+	{"reemplazar un partido con sus contadores", checkReplace},
+	{"corregir contadores con eventos", checkCorrect},
+	{"versiones del partido", checkVersions},
+	{"listar partidos", checkList},
+	{"filtrar y paginar partidos", checkListPages},
+	{"buscar por equipo", checkSearch},
+	{"equipos", checkTeams},
+	{"jugadores", checkPlayers},
+	{"marcador por equipo", checkScores},
+	{"goles sin equipo", checkUnattributedGoals},
+	{"incrementar tarjetas", checkCardCounters},
+	{"cronología del partido", checkEvents},
+	{"anular eventos", checkVoidEvents},
+	{"tope de tiempo extra", checkExtraTimeCap},
+	{"incrementos concurrentes", checkConcurrentIncrements},
+	{"eliminar un partido", checkDelete},
+	{"claves de idempotencia", checkIdempotencyKeys},
+	{"ping y versión del esquema", checkPing},
+}
+
+func TestMemoryStoreConformance(t *testing.T) {
+	runStoreChecks(t, newMemoryStore())
+}
+
+// TestPostgresStoreConformance ejecuta la batería contra la base de datos de
+// STORE_TEST_DSN, aplicando antes las migraciones pendientes
+func TestPostgresStoreConformance(t *testing.T) {
+	dsn := os.Getenv("STORE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("STORE_TEST_DSN no está definida")
+	}
+	pool, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	defer pool.Close()
+	if err := applyMigrations(pool); err != nil {
+		t.Fatalf("applyMigrations: %v", err)
+	}
+	runStoreChecks(t, newPostgresStore(pool))
+}
+
+// runStoreChecks ejecuta cada verificación de la batería contra s como un
+// subtest
+func runStoreChecks(t *testing.T, s MatchStore) {
+	ctx := context.Background()
+	if err := createCheckTeams(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		s.DeleteTeam(ctx, checkHome.ID)
+		s.DeleteTeam(ctx, checkAway.ID)
+	}()
+
+	for _, check := range storeChecks {
+		t.Run(check.name, func(t *testing.T) {
+			if err := check.fn(ctx, s); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// checkHome y checkAway son los equipos de checkInput; createCheckTeams los
+// crea con nombres únicos antes de la batería
+var checkHome, checkAway Team
+
+var checkInput = MatchInput{
+	MatchDate: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func createCheckTeams(ctx context.Context, s MatchStore) error {
+	suffix := time.Now().UnixNano()
+	var err error
+	if checkHome, err = s.CreateTeam(ctx, TeamInput{Name: fmt.Sprintf("Conformidad Local %d", suffix)}); err != nil {
+		return fmt.Errorf("CreateTeam: %w", err)
+	}
+	if checkAway, err = s.CreateTeam(ctx, TeamInput{Name: fmt.Sprintf("Conformidad Visitante %d", suffix)}); err != nil {
+		s.DeleteTeam(context.WithoutCancel(ctx), checkHome.ID)
+		return fmt.Errorf("CreateTeam: %w", err)
+	}
+	checkInput.HomeTeamID, checkInput.AwayTeamID = checkHome.ID, checkAway.ID
+	return nil
+}
+
+// withMatch crea un partido temporal, ejecuta fn y lo elimina al terminar
+func withMatch(ctx context.Context, s MatchStore, fn func(m Match) error) error {
+	m, err := s.CreateMatch(ctx, checkInput)
+	if err != nil {
+		return fmt.Errorf("CreateMatch: %w", err)
+	}
+	defer s.DeleteMatch(context.WithoutCancel(ctx), m.ID, 0)
+	return fn(m)
+}
+
+func expectNotFound(op string, err error) error {
+	if !errors.Is(err, ErrMatchNotFound) {
+		return fmt.Errorf("%s sobre un partido inexistente retornó %v, se esperaba ErrMatchNotFound", op, err)
+	}
+	return nil
+}
+
+func checkCreateAndGet(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(created Match) error {
+		if created.ID <= 0 {
+			return fmt.Errorf("CreateMatch asignó el id %d", created.ID)
+		}
+		got, err := s.GetMatch(ctx, created.ID)
+		if err != nil {
+			return fmt.Errorf("GetMatch: %w", err)
+		}
+		if got.HomeTeamID != checkHome.ID || got.HomeTeam != checkHome.Name ||
+			got.AwayTeamID != checkAway.ID || got.AwayTeam != checkAway.Name ||
+			!got.MatchDate.Equal(checkInput.MatchDate) {
+			return fmt.Errorf("GetMatch retornó %+v, se esperaba %+v entre %q y %q", got, checkInput, checkHome.Name, checkAway.Name)
+		}
+		if got.YellowCards != 0 || got.RedCards != 0 || got.ExtraTime != 0 {
+			return fmt.Errorf("un partido nuevo debe iniciar sus contadores en 0: %+v", got)
+		}
+		return nil
+	})
+}
+
+func checkCreateBatch(ctx context.Context, s MatchStore) error {
+	second := checkInput
+	second.MatchDate = checkInput.MatchDate.AddDate(0, 0, 1)
+	created, err := s.CreateMatches(ctx, []MatchInput{checkInput, second})
+	if err != nil {
+		return fmt.Errorf("CreateMatches: %w", err)
+	}
+	for _, m := range created {
+		defer s.DeleteMatch(context.WithoutCancel(ctx), m.ID, 0)
+	}
+	if len(created) != 2 || created[0].ID <= 0 || created[0].ID == created[1].ID {
+		return fmt.Errorf("CreateMatches retornó %+v", created)
+	}
+	got, err := s.GetMatch(ctx, created[1].ID)
+	if err != nil {
+		return fmt.Errorf("GetMatch: %w", err)
+	}
+	if !got.MatchDate.Equal(second.MatchDate) || got.Version != created[1].Version {
+		return fmt.Errorf("GetMatch retornó %+v, se esperaba %+v", got, created[1])
+	}
+	return nil
+}
+
+func checkNotFound(ctx context.Context, s MatchStore) error {
+	const missing = -1
+	_, err := s.GetMatch(ctx, missing)
+	if err := expectNotFound("GetMatch", err); err != nil {
+		return err
+	}
+	_, err = s.UpdateMatch(ctx, missing, checkInput)
+	if err := expectNotFound("UpdateMatch", err); err != nil {
+		return err
+	}
+	if err := expectNotFound("DeleteMatch", s.DeleteMatch(ctx, missing, 0)); err != nil {
+		return err
+	}
+	for _, eventType := range []string{eventGoal, eventSubstitution, eventExtraTime} {
+		_, _, err = s.AddMatchEvent(ctx, MatchEventInput{MatchID: missing, Type: eventType, Team: sideHome})
+		if err := expectNotFound("AddMatchEvent("+eventType+")", err); err != nil {
+			return err
+		}
+	}
+	_, err = s.ListMatchEvents(ctx, missing)
+	return expectNotFound("ListMatchEvents", err)
+}
+
+func checkUpdate(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		in := MatchInput{
+			HomeTeamID: checkAway.ID,
+			AwayTeamID: checkHome.ID,
+			MatchDate:  checkInput.MatchDate.AddDate(0, 0, 7),
+		}
+		updated, err := s.UpdateMatch(ctx, m.ID, in)
+		if err != nil {
+			return fmt.Errorf("UpdateMatch: %w", err)
+		}
+		if updated.ID != m.ID || updated.HomeTeamID != checkAway.ID || updated.HomeTeam != checkAway.Name ||
+			updated.AwayTeam != checkHome.Name || !updated.MatchDate.Equal(in.MatchDate) {
+			return fmt.Errorf("UpdateMatch retornó %+v", updated)
+		}
+		got, err := s.GetMatch(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("GetMatch: %w", err)
+		}
+		if got.HomeTeamID != in.HomeTeamID || got.AwayTeam != checkHome.Name || !got.MatchDate.Equal(in.MatchDate) {
+			return fmt.Errorf("el cambio no se persistió: %+v", got)
+		}
+		unknown := in
+		unknown.AwayTeamID = -1
+		if _, err := s.UpdateMatch(ctx, m.ID, unknown); !errors.Is(err, ErrUnknownTeam) {
+			return fmt.Errorf("UpdateMatch con un equipo inexistente retornó %v, se esperaba ErrUnknownTeam", err)
+		}
+		return nil
+	})
+}
+
+// checkKickoff verifica que la hora de inicio se guarde y que UpdateMatch sin
+// hora la conserve solo si la fecha no cambia
+func checkKickoff(ctx context.Context, s MatchStore) error {
+	kickoff := time.Date(2025, 4, 1, 21, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	in := checkInput
+	in.Kickoff = &kickoff
+	created, err := s.CreateMatch(ctx, in)
+	if err != nil {
+		return fmt.Errorf("CreateMatch: %w", err)
+	}
+	defer s.DeleteMatch(context.WithoutCancel(ctx), created.ID, 0)
+	if created.Kickoff == nil || !created.Kickoff.Equal(kickoff) {
+		return fmt.Errorf("CreateMatch guardó la hora de inicio %v, se esperaba %v", created.Kickoff, kickoff)
+	}
+
+	in.Kickoff = nil
+	steps := []struct {
+		name string
+		date time.Time
+		want *time.Time
+	}{
+		{"la misma fecha", in.MatchDate, &kickoff},
+		{"otra fecha", in.MatchDate.AddDate(0, 0, 1), nil},
+	}
+	for _, step := range steps {
+		in.MatchDate = step.date
+		if _, err := s.UpdateMatch(ctx, created.ID, in); err != nil {
+			return fmt.Errorf("UpdateMatch con %s: %w", step.name, err)
+		}
+		got, err := s.GetMatch(ctx, created.ID)
+		if err != nil {
+			return fmt.Errorf("GetMatch: %w", err)
+		}
+		if (got.Kickoff == nil) != (step.want == nil) || got.Kickoff != nil && !got.Kickoff.Equal(*step.want) {
+			return fmt.Errorf("UpdateMatch sin hora con %s dejó %v, se esperaba %v", step.name, got.Kickoff, step.want)
+		}
+	}
+	return nil
+}
+
+func checkReplace(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		want := m
+		want.HomeTeamID, want.HomeTeam = checkAway.ID, checkAway.Name
+		want.AwayTeamID, want.AwayTeam = checkHome.ID, checkHome.Name
+		want.Goals, want.YellowCards, want.RedCards, want.ExtraTime = 3, 2, 1, 5
+		want.HomeScore, want.AwayScore = 2, 1
+		if _, err := s.ReplaceMatch(ctx, want); err != nil {
+			return fmt.Errorf("ReplaceMatch: %w", err)
+		}
+		want.Version++
+		got, err := s.GetMatch(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("GetMatch: %w", err)
+		}
+		if got.UpdatedAt.Before(m.UpdatedAt) {
+			return fmt.Errorf("ReplaceMatch dejó updatedAt en %v, antes de %v", got.UpdatedAt, m.UpdatedAt)
+		}
+		want.UpdatedAt = got.UpdatedAt
+		if got != want {
+			return fmt.Errorf("GetMatch retornó %+v, se esperaba %+v", got, want)
+		}
+		missing := want
+		missing.ID = -1
+		_, err = s.ReplaceMatch(ctx, missing)
+		return expectNotFound("ReplaceMatch", err)
+	})
+}
+
+// checkVersions verifica que cada escritura aumente la versión sin retroceder
+// updatedAt y que las escrituras con una versión desactualizada fallen sin
+// modificar el partido
+func checkCorrect(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		goal, m, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventGoal, Team: sideHome})
+		if err != nil {
+			return fmt.Errorf("AddMatchEvent: %w", err)
+		}
+		// Una roja sin evento, como las anteriores a la cronología
+		m.RedCards = 1
+		if m, err = s.ReplaceMatch(ctx, m); err != nil {
+			return fmt.Errorf("ReplaceMatch: %w", err)
+		}
+
+		want := m
+		want.Goals, want.HomeScore, want.AwayScore, want.YellowCards, want.RedCards = 2, 0, 2, 1, 0
+		got, err := s.CorrectMatch(ctx, want, "corrección")
+		if err != nil {
+			return fmt.Errorf("CorrectMatch: %w", err)
+		}
+		if got.Goals != 2 || got.HomeScore != 0 || got.AwayScore != 2 || got.YellowCards != 1 || got.RedCards != 0 || got.Version != m.Version+1 {
+			return fmt.Errorf("CorrectMatch retornó %+v", got)
+		}
+
+		events, err := s.ListMatchEvents(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("ListMatchEvents: %w", err)
+		}
+		var voided, added []string
+		for _, e := range events {
+			switch {
+			case e.ID == goal.ID && e.VoidedAt != nil && e.VoidReason == "corrección":
+			case e.VoidedAt != nil && e.VoidReason == "corrección":
+				voided = append(voided, e.Type+"/"+e.Team)
+			case e.VoidedAt == nil && e.Minute == nil:
+				added = append(added, e.Type+"/"+e.Team)
+			default:
+				return fmt.Errorf("CorrectMatch dejó el evento %+v", e)
+			}
+		}
+		if fmt.Sprint(voided) != "[red_card/]" || fmt.Sprint(added) != "[goal/away goal/away yellow_card/]" {
+			return fmt.Errorf("CorrectMatch anuló %v y agregó %v", voided, added)
+		}
+
+		if _, err := s.CorrectMatch(ctx, want, "corrección"); !errors.Is(err, ErrVersionMismatch) {
+			return fmt.Errorf("CorrectMatch con una versión vieja retornó %v, se esperaba ErrVersionMismatch", err)
+		}
+		missing := want
+		missing.ID, missing.Version = -1, 0
+		_, err = s.CorrectMatch(ctx, missing, "corrección")
+		return expectNotFound("CorrectMatch", err)
+	})
+}
+
+func checkVersions(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		stale := m.Version
+		in := checkInput
+		in.Version = stale
+		updated, err := s.UpdateMatch(ctx, m.ID, in)
+		if err != nil {
+			return fmt.Errorf("UpdateMatch con la versión actual: %w", err)
+		}
+		if updated.Version != stale+1 {
+			return fmt.Errorf("UpdateMatch dejó la versión en %d, se esperaba %d", updated.Version, stale+1)
+		}
+		if updated.UpdatedAt.Before(m.UpdatedAt) {
+			return fmt.Errorf("UpdateMatch dejó updatedAt en %v, antes de %v", updated.UpdatedAt, m.UpdatedAt)
+		}
+		_, scored, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventGoal, Team: sideAway})
+		if err != nil {
+			return fmt.Errorf("AddMatchEvent: %w", err)
+		}
+		if scored.Version != updated.Version+1 {
+			return fmt.Errorf("AddMatchEvent dejó la versión en %d, se esperaba %d", scored.Version, updated.Version+1)
+		}
+
+		if _, err := s.UpdateMatch(ctx, m.ID, in); !errors.Is(err, ErrVersionMismatch) {
+			return fmt.Errorf("UpdateMatch con una versión vieja retornó %v, se esperaba ErrVersionMismatch", err)
+		}
+		if _, err := s.ReplaceMatch(ctx, m); !errors.Is(err, ErrVersionMismatch) {
+			return fmt.Errorf("ReplaceMatch con una versión vieja retornó %v, se esperaba ErrVersionMismatch", err)
+		}
+		if err := s.DeleteMatch(ctx, m.ID, stale); !errors.Is(err, ErrVersionMismatch) {
+			return fmt.Errorf("DeleteMatch con una versión vieja retornó %v, se esperaba ErrVersionMismatch", err)
+		}
+		got, err := s.GetMatch(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("GetMatch: %w", err)
+		}
+		if got != scored {
+			return fmt.Errorf("una escritura rechazada modificó el partido: %+v, se esperaba %+v", got, scored)
+		}
+		return nil
+	})
+}
+
+func checkList(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		page, err := s.ListMatches(ctx, MatchQuery{Sort: sortByID})
+		if err != nil {
+			return fmt.Errorf("ListMatches: %w", err)
+		}
+		for i, listed := range page.Matches {
+			if i > 0 && page.Matches[i-1].ID >= listed.ID {
+				return fmt.Errorf("ListMatches debe ordenar por id ascendente")
+			}
+		}
+		if page.Total != len(page.Matches) {
+			return fmt.Errorf("ListMatches sin límite retornó %d partidos y un total de %d", len(page.Matches), page.Total)
+		}
+		for _, listed := range page.Matches {
+			if listed.ID == m.ID {
+				return nil
+			}
+		}
+		return fmt.Errorf("ListMatches no incluye el partido %d", m.ID)
+	})
+}
+
+// checkListPages crea tres partidos con un equipo único y los recorre
+// filtrando, ordenando por fecha descendente y paginando de dos en dos
+func checkListPages(ctx context.Context, s MatchStore) error {
+	team, err := s.CreateTeam(ctx, TeamInput{Name: fmt.Sprintf("Conformidad Paginación %d", time.Now().UnixNano())})
+	if err != nil {
+		return fmt.Errorf("CreateTeam: %w", err)
+	}
+	defer s.DeleteTeam(context.WithoutCancel(ctx), team.ID)
+	dates := []time.Time{
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	var ids []int
+	defer func() {
+		for _, id := range ids {
+			s.DeleteMatch(context.WithoutCancel(ctx), id, 0)
+		}
+	}()
+	for _, d := range dates {
+		m, err := s.CreateMatch(ctx, MatchInput{HomeTeamID: checkHome.ID, AwayTeamID: team.ID, MatchDate: d})
+		if err != nil {
+			return fmt.Errorf("CreateMatch: %w", err)
+		}
+		ids = append(ids, m.ID)
+	}
+
+	q := MatchQuery{Team: strings.ToUpper(team.Name), Sort: sortByDate, Desc: true, Limit: 2}
+	first, err := s.ListMatches(ctx, q)
+	if err != nil {
+		return fmt.Errorf("ListMatches: %w", err)
+	}
+	if first.Total != 3 || len(first.Matches) != 2 {
+		return fmt.Errorf("primera página: se esperaban 2 de 3 partidos, se obtuvo %d de %d", len(first.Matches), first.Total)
+	}
+	if first.Matches[0].ID != ids[1] || first.Matches[1].ID != ids[2] {
+		return fmt.Errorf("primera página en orden incorrecto: %d, %d", first.Matches[0].ID, first.Matches[1].ID)
+	}
+
+	last := first.Matches[1]
+	q.After = &MatchCursor{ID: last.ID, MatchDate: last.MatchDate}
+	next, err := s.ListMatches(ctx, q)
+	if err != nil {
+		return fmt.Errorf("ListMatches con cursor: %w", err)
+	}
+	if len(next.Matches) != 1 || next.Matches[0].ID != ids[0] {
+		return fmt.Errorf("la página siguiente al cursor debe contener solo el partido %d", ids[0])
+	}
+
+	q.After, q.Offset = nil, 2
+	byOffset, err := s.ListMatches(ctx, q)
+	if err != nil {
+		return fmt.Errorf("ListMatches con offset: %w", err)
+	}
+	if len(byOffset.Matches) != 1 || byOffset.Matches[0].ID != ids[0] {
+		return fmt.Errorf("offset 2 debe retornar solo el partido %d", ids[0])
+	}
+
+	ranged, err := s.ListMatches(ctx, MatchQuery{AwayTeam: team.Name, From: dates[2], To: dates[1], Sort: sortByID})
+	if err != nil {
+		return fmt.Errorf("ListMatches con rango de fechas: %w", err)
+	}
+	if ranged.Total != 2 {
+		return fmt.Errorf("el rango de fechas debe incluir 2 partidos, se obtuvo %d", ranged.Total)
+	}
+
+	byTeam, err := s.ListMatches(ctx, MatchQuery{TeamID: team.ID, Sort: sortByID})
+	if err != nil {
+		return fmt.Errorf("ListMatches por id de equipo: %w", err)
+	}
+	if byTeam.Total != 3 {
+		return fmt.Errorf("el filtro por id de equipo debe incluir 3 partidos, se obtuvo %d", byTeam.Total)
+	}
+	return nil
+}
+
+// checkSearch busca un equipo con acentos usando un prefijo sin acentos
+func checkSearch(ctx context.Context, s MatchStore) error {
+	team, err := s.CreateTeam(ctx, TeamInput{Name: fmt.Sprintf("Atlético Conformidad %d", time.Now().UnixNano())})
+	if err != nil {
+		return fmt.Errorf("CreateTeam: %w", err)
+	}
+	defer s.DeleteTeam(context.WithoutCancel(ctx), team.ID)
+	in := checkInput
+	in.HomeTeamID = team.ID
+	m, err := s.CreateMatch(ctx, in)
+	if err != nil {
+		return fmt.Errorf("CreateMatch: %w", err)
+	}
+	defer s.DeleteMatch(context.WithoutCancel(ctx), m.ID, 0)
+
+	hits, err := s.SearchMatches(ctx, MatchSearch{Terms: []string{"atletico conformid"}, MinScore: searchMinScore, Limit: 50})
+	if err != nil {
+		return fmt.Errorf("SearchMatches: %w", err)
+	}
+	for i, hit := range hits {
+		if i > 0 && hits[i-1].Score < hit.Score {
+			return fmt.Errorf("SearchMatches debe ordenar por relevancia descendente")
+		}
+		if hit.Match.ID == m.ID {
+			if hit.Score != 1 {
+				return fmt.Errorf("un prefijo debe puntuar 1, se obtuvo %v", hit.Score)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("SearchMatches no encontró %q", team.Name)
+}
+
+// checkTeams verifica el ciclo de un equipo: la búsqueda por nombre, nombre
+// corto y código, los conflictos, el cambio de nombre en sus partidos y que
+// no se pueda eliminar mientras juegue alguno
+func checkTeams(ctx context.Context, s MatchStore) error {
+	suffix := time.Now().UnixNano()
+	in := TeamInput{
+		Name:      fmt.Sprintf("Club Conformidad %d", suffix),
+		ShortName: fmt.Sprintf("Conformidad %d", suffix),
+		Colors:    []string{"#FFFFFF"},
+	}
+	team, err := s.CreateTeam(ctx, in)
+	if err != nil {
+		return fmt.Errorf("CreateTeam: %w", err)
+	}
+	defer s.DeleteTeam(context.WithoutCancel(ctx), team.ID)
+	if team.ID <= 0 || team.Name != in.Name || team.ShortName != in.ShortName || len(team.Colors) != 1 {
+		return fmt.Errorf("CreateTeam retornó %+v", team)
+	}
+	got, err := s.GetTeam(ctx, team.ID)
+	if err != nil {
+		return fmt.Errorf("GetTeam: %w", err)
+	}
+	if got.Name != team.Name || got.Code != "" || got.Founded != 0 {
+		return fmt.Errorf("GetTeam retornó %+v, se esperaba %+v", got, team)
+	}
+
+	for _, name := range []string{in.Name, strings.ToUpper(in.ShortName)} {
+		found, err := s.FindTeam(ctx, []string{normalizeTeam(name)})
+		if err != nil || found.ID != team.ID {
+			return fmt.Errorf("FindTeam(%q) retornó %+v, %v", name, found, err)
+		}
+	}
+	if _, err := s.FindTeam(ctx, []string{"conformidad inexistente"}); !errors.Is(err, ErrTeamNotFound) {
+		return fmt.Errorf("FindTeam de un nombre inexistente retornó %v, se esperaba ErrTeamNotFound", err)
+	}
+	if _, err := s.CreateTeam(ctx, TeamInput{Name: strings.ToUpper(in.Name)}); !errors.Is(err, ErrTeamConflict) {
+		return fmt.Errorf("CreateTeam con un nombre repetido retornó %v, se esperaba ErrTeamConflict", err)
+	}
+
+	if _, err := s.CreateMatch(ctx, MatchInput{HomeTeamID: team.ID, AwayTeamID: -1, MatchDate: checkInput.MatchDate}); !errors.Is(err, ErrUnknownTeam) {
+		return fmt.Errorf("CreateMatch con un equipo inexistente retornó %v, se esperaba ErrUnknownTeam", err)
+	}
+	m, err := s.CreateMatch(ctx, MatchInput{HomeTeamID: team.ID, AwayTeamID: checkAway.ID, MatchDate: checkInput.MatchDate})
+	if err != nil {
+		return fmt.Errorf("CreateMatch: %w", err)
+	}
+	defer s.DeleteMatch(context.WithoutCancel(ctx), m.ID, 0)
+
+	renamed := in
+	renamed.Name += " Renombrado"
+	if _, err := s.UpdateTeam(ctx, team.ID, renamed); err != nil {
+		return fmt.Errorf("UpdateTeam: %w", err)
+	}
+	updated, err := s.GetMatch(ctx, m.ID)
+	if err != nil {
+		return fmt.Errorf("GetMatch: %w", err)
+	}
+	if updated.HomeTeam != renamed.Name || updated.Version != m.Version+1 {
+		return fmt.Errorf("el cambio de nombre dejó el partido en %+v", updated)
+	}
+	if err := s.DeleteTeam(ctx, team.ID); !errors.Is(err, ErrTeamInUse) {
+		return fmt.Errorf("DeleteTeam de un equipo con partidos retornó %v, se esperaba ErrTeamInUse", err)
+	}
+
+	if err := s.DeleteMatch(ctx, m.ID, 0); err != nil {
+		return fmt.Errorf("DeleteMatch: %w", err)
+	}
+	if err := s.DeleteTeam(ctx, team.ID); err != nil {
+		return fmt.Errorf("DeleteTeam: %w", err)
+	}
+	if _, err := s.GetTeam(ctx, team.ID); !errors.Is(err, ErrTeamNotFound) {
+		return fmt.Errorf("GetTeam de un equipo eliminado retornó %v, se esperaba ErrTeamNotFound", err)
+	}
+	return nil
+}
+
+func checkScores(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		for _, side := range []string{sideHome, sideAway, sideHome} {
+			if _, _, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventGoal, Team: side}); err != nil {
+				return fmt.Errorf("AddMatchEvent(%s): %w", side, err)
+			}
+		}
+		got, err := s.GetMatch(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("GetMatch: %w", err)
+		}
+		if got.HomeScore != 2 || got.AwayScore != 1 || got.Goals != 3 {
+			return fmt.Errorf("se esperaba 2-1 con 3 goles, se obtuvo %d-%d con %d", got.HomeScore, got.AwayScore, got.Goals)
+		}
+		if got.result() != resultHome {
+			return fmt.Errorf("se esperaba el resultado %q, se obtuvo %q", resultHome, got.result())
+		}
+		return nil
+	})
+}
+
+func checkUnattributedGoals(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		// Un gol sin evento ni equipo, como los anteriores al marcador por equipo
+		legacy := m
+		legacy.Goals = 1
+		if _, err := s.ReplaceMatch(ctx, legacy); err != nil {
+			return fmt.Errorf("ReplaceMatch: %w", err)
+		}
+		home, _, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventGoal, Team: sideHome})
+		if err != nil {
+			return fmt.Errorf("AddMatchEvent: %w", err)
+		}
+		reversal := MatchEventVoid{MatchID: m.ID, Type: eventGoal, Reason: "mal registrado"}
+		goal, updated, err := s.VoidMatchEvent(ctx, reversal)
+		if err != nil {
+			return fmt.Errorf("VoidMatchEvent de un gol sin equipo: %w", err)
+		}
+		if goal.ID == home.ID || goal.Team != "" || updated.Goals != 1 || updated.HomeScore != 1 || updated.AwayScore != 0 {
+			return fmt.Errorf("VoidMatchEvent de un gol sin equipo retornó %+v y %+v", goal, updated)
+		}
+		// Los goles restantes tienen equipo
+		if _, _, err := s.VoidMatchEvent(ctx, reversal); !errors.Is(err, ErrCounterBelowZero) {
+			return fmt.Errorf("VoidMatchEvent sin goles sin equipo retornó %v", err)
+		}
+		events, err := s.ListMatchEvents(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("ListMatchEvents: %w", err)
+		}
+		if len(events) != 2 || events[0].ID != home.ID || events[0].VoidedAt != nil {
+			return fmt.Errorf("se esperaba el gol local vigente y el gol sin equipo anulado, se obtuvo %+v", events)
+		}
+		return nil
+	})
+}
+
+func checkCardCounters(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		for _, eventType := range []string{eventYellowCard, eventYellowCard, eventRedCard} {
+			if _, _, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventType}); err != nil {
+				return fmt.Errorf("AddMatchEvent(%s): %w", eventType, err)
+			}
+		}
+		got, err := s.GetMatch(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("GetMatch: %w", err)
+		}
+		if got.YellowCards != 2 || got.RedCards != 1 {
+			return fmt.Errorf("se esperaban 2 amarillas y 1 roja, se obtuvo %d y %d", got.YellowCards, got.RedCards)
+		}
+		return nil
+	})
+}
+
+// checkEvents verifica que cada evento quede en la cronología con sus datos y
+// que solo los eventos con contador cambien el partido
+func checkEvents(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		minute := 45
+		goal := MatchEventInput{MatchID: m.ID, Type: eventGoal, Minute: &minute, AddedTime: 2, Team: sideHome, Player: "Conformidad"}
+		added, scored, err := s.AddMatchEvent(ctx, goal)
+		if err != nil {
+			return fmt.Errorf("AddMatchEvent: %w", err)
+		}
+		if added.ID <= 0 || added.MatchID != m.ID || scored.HomeScore != 1 || scored.Version != m.Version+1 {
+			return fmt.Errorf("AddMatchEvent retornó %+v y %+v", added, scored)
+		}
+		_, substituted, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventSubstitution, Team: sideAway})
+		if err != nil {
+			return fmt.Errorf("AddMatchEvent: %w", err)
+		}
+		if substituted.Version != scored.Version {
+			return fmt.Errorf("una sustitución cambió la versión del partido a %d", substituted.Version)
+		}
+
+		events, err := s.ListMatchEvents(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("ListMatchEvents: %w", err)
+		}
+		if len(events) != 2 || events[0].ID != added.ID || events[1].Type != eventSubstitution {
+			return fmt.Errorf("ListMatchEvents retornó %+v", events)
+		}
+		got := events[0]
+		if got.Minute == nil || *got.Minute != minute || got.AddedTime != 2 || got.Team != sideHome || got.Player != goal.Player {
+			return fmt.Errorf("ListMatchEvents retornó el gol %+v, se esperaba %+v", got, goal)
+		}
+		if events[1].Minute != nil || events[1].Player != "" {
+			return fmt.Errorf("un evento sin minuto ni jugador se guardó como %+v", events[1])
+		}
+		return nil
+	})
+}
+
+// checkVoidEvents verifica que anular un evento descuente su contador sin
+// quitarlo de la cronología y que nunca deje un contador en negativo
+func checkVoidEvents(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		goal, _, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventGoal, Team: sideHome})
+		if err != nil {
+			return fmt.Errorf("AddMatchEvent: %w", err)
+		}
+		v := MatchEventVoid{MatchID: m.ID, EventID: goal.ID, Reason: "fuera de juego"}
+		voided, updated, err := s.VoidMatchEvent(ctx, v)
+		if err != nil {
+			return fmt.Errorf("VoidMatchEvent: %w", err)
+		}
+		if voided.ID != goal.ID || voided.VoidedAt == nil || voided.VoidReason != v.Reason {
+			return fmt.Errorf("VoidMatchEvent retornó el evento %+v", voided)
+		}
+		if updated.Goals != 0 || updated.HomeScore != 0 || updated.Version != m.Version+2 {
+			return fmt.Errorf("VoidMatchEvent retornó el partido %+v", updated)
+		}
+		if _, _, err := s.VoidMatchEvent(ctx, v); !errors.Is(err, ErrEventAlreadyVoided) {
+			return fmt.Errorf("anular dos veces retornó %v, se esperaba ErrEventAlreadyVoided", err)
+		}
+		missing := v
+		missing.EventID = -1
+		if _, _, err := s.VoidMatchEvent(ctx, missing); !errors.Is(err, ErrEventNotFound) {
+			return fmt.Errorf("anular un evento inexistente retornó %v, se esperaba ErrEventNotFound", err)
+		}
+		latest := MatchEventVoid{MatchID: m.ID, Type: eventGoal, Team: sideHome, Reason: "sin goles"}
+		if _, _, err := s.VoidMatchEvent(ctx, latest); !errors.Is(err, ErrCounterBelowZero) {
+			return fmt.Errorf("descontar un gol con el marcador en cero retornó %v, se esperaba ErrCounterBelowZero", err)
+		}
+
+		// Una tarjeta sin evento, como las anteriores a la cronología
+		legacy := updated
+		legacy.YellowCards = 1
+		if _, err := s.ReplaceMatch(ctx, legacy); err != nil {
+			return fmt.Errorf("ReplaceMatch: %w", err)
+		}
+		card, corrected, err := s.VoidMatchEvent(ctx, MatchEventVoid{MatchID: m.ID, Type: eventYellowCard, Reason: "revocada"})
+		if err != nil {
+			return fmt.Errorf("VoidMatchEvent sin evento: %w", err)
+		}
+		if corrected.YellowCards != 0 || card.ID <= 0 || card.VoidedAt == nil {
+			return fmt.Errorf("VoidMatchEvent sin evento retornó %+v y %+v", card, corrected)
+		}
+
+		events, err := s.ListMatchEvents(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("ListMatchEvents: %w", err)
+		}
+		if len(events) != 2 || events[0].VoidedAt == nil || events[1].ID != card.ID || events[1].VoidReason != "revocada" {
+			return fmt.Errorf("ListMatchEvents retornó %+v", events)
+		}
+		return nil
+	})
+}
+
+func checkExtraTimeCap(ctx context.Context, s MatchStore) error {
+	return withMatch(ctx, s, func(m Match) error {
+		extraTime := MatchEventInput{MatchID: m.ID, Type: eventExtraTime}
+		for i := 1; i <= maxExtraTime; i++ {
+			_, updated, err := s.AddMatchEvent(ctx, extraTime)
+			if err != nil {
+				return fmt.Errorf("AddMatchEvent: %w", err)
+			}
+			if updated.ExtraTime != i {
+				return fmt.Errorf("AddMatchEvent retornó %d minutos de tiempo extra, se esperaba %d", updated.ExtraTime, i)
+			}
+		}
+		if _, _, err := s.AddMatchEvent(ctx, extraTime); !errors.Is(err, ErrExtraTimeLimit) {
+			return fmt.Errorf("AddMatchEvent en el máximo retornó %v, se esperaba ErrExtraTimeLimit", err)
+		}
+		events, err := s.ListMatchEvents(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("ListMatchEvents: %w", err)
+		}
+		if len(events) != maxExtraTime {
+			return fmt.Errorf("se esperaban %d eventos, se obtuvo %d", maxExtraTime, len(events))
+		}
+		return nil
+	})
+}
+
+func checkConcurrentIncrements(ctx context.Context, s MatchStore) error {
+	const workers = 20
+	return withMatch(ctx, s, func(m Match) error {
+		var wg sync.WaitGroup
+		errs := make(chan error, workers)
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, _, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventYellowCard}); err != nil {
+					errs <- err
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		if err := <-errs; err != nil {
+			return fmt.Errorf("AddMatchEvent: %w", err)
+		}
+
+		got, err := s.GetMatch(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("GetMatch: %w", err)
+		}
+		if got.YellowCards != workers {
+			return fmt.Errorf("se esperaban %d amarillas tras incrementos concurrentes, se obtuvo %d", workers, got.YellowCards)
+		}
+		return nil
+	})
+}
+
+func checkDelete(ctx context.Context, s MatchStore) error {
+	m, err := s.CreateMatch(ctx, checkInput)
+	if err != nil {
+		return fmt.Errorf("CreateMatch: %w", err)
+	}
+	if err := s.DeleteMatch(ctx, m.ID, m.Version); err != nil {
+		return fmt.Errorf("DeleteMatch: %w", err)
+	}
+	_, err = s.GetMatch(ctx, m.ID)
+	if err := expectNotFound("GetMatch", err); err != nil {
+		return err
+	}
+	return expectNotFound("DeleteMatch", s.DeleteMatch(ctx, m.ID, 0))
+}
+
+// checkIdempotencyKeys verifica el ciclo de una Idempotency-Key: reservar,
+// detectar la repetición y guardar la respuesta. El vencimiento no se prueba
+// porque eliminaría las claves vigentes de otros clientes.
+func checkIdempotencyKeys(ctx context.Context, s MatchStore) error {
+	rec := IdempotencyRecord{Key: fmt.Sprintf("conformance-%d", time.Now().UnixNano()), Fingerprint: "POST /api/matches"}
+	longAgo := time.Now().Add(-time.Hour)
+
+	if _, reserved, err := s.ReserveIdempotencyKey(ctx, rec, longAgo); err != nil || !reserved {
+		return fmt.Errorf("ReserveIdempotencyKey de una clave nueva retornó %t, %v", reserved, err)
+	}
+	stored, reserved, err := s.ReserveIdempotencyKey(ctx, rec, longAgo)
+	if err != nil || reserved || stored.Status != 0 || stored.Fingerprint != rec.Fingerprint {
+		return fmt.Errorf("ReserveIdempotencyKey de una clave en curso retornó %+v, %t, %v", stored, reserved, err)
+	}
+
+	rec.Status, rec.Body = 201, []byte(`{"id":1}`)
+	rec.Header = http.Header{"Content-Type": {"application/json"}}
+	if err := s.CompleteIdempotencyKey(ctx, rec); err != nil {
+		return fmt.Errorf("CompleteIdempotencyKey: %w", err)
+	}
+	// Una clave con respuesta no se libera
+	if err := s.ReleaseIdempotencyKey(ctx, rec.Key); err != nil {
+		return fmt.Errorf("ReleaseIdempotencyKey: %w", err)
+	}
+	stored, reserved, err = s.ReserveIdempotencyKey(ctx, rec, longAgo)
+	if err != nil || reserved || stored.Status != rec.Status || string(stored.Body) != string(rec.Body) ||
+		stored.Header.Get("Content-Type") != "application/json" {
+		return fmt.Errorf("ReserveIdempotencyKey de una clave completada retornó %+v, %t, %v", stored, reserved, err)
+	}
+
+	// Una clave vencida se reemplaza por la nueva reserva
+	other := IdempotencyRecord{Key: rec.Key, Fingerprint: "PATCH /api/matches/1/goals"}
+	stored, reserved, err = s.ReserveIdempotencyKey(ctx, other, time.Now().Add(time.Hour))
+	if err != nil || !reserved || stored.Fingerprint != other.Fingerprint {
+		return fmt.Errorf("ReserveIdempotencyKey de una clave vencida retornó %+v, %t, %v", stored, reserved, err)
+	}
+	purged, err := s.PurgeIdempotencyKeys(ctx, stored.CreatedAt.Add(time.Microsecond))
+	if err != nil || purged < 1 {
+		return fmt.Errorf("PurgeIdempotencyKeys retornó %d, %v", purged, err)
+	}
+	if _, reserved, err := s.ReserveIdempotencyKey(ctx, rec, longAgo); err != nil || !reserved {
+		return fmt.Errorf("ReserveIdempotencyKey de una clave eliminada retornó %t, %v", reserved, err)
+	}
+	return s.ReleaseIdempotencyKey(ctx, rec.Key)
+}
+
+func checkPing(ctx context.Context, s MatchStore) error {
+	if err := s.Ping(ctx); err != nil {
+		return fmt.Errorf("Ping: %w", err)
+	}
+	version, err := s.SchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("SchemaVersion: %w", err)
+	}
+	if version != expectedSchemaVersion {
+		return fmt.Errorf("SchemaVersion retornó %d, se esperaba %d", version, expectedSchemaVersion)
+	}
+	return nil
+}
+
+// checkPlayers verifica la plantilla de un equipo por temporada, el dorsal
+// único y la atribución de eventos: el jugador define el equipo del evento y
+// debe jugar el partido en su temporada
+func checkPlayers(ctx context.Context, s MatchStore) error {
+	season := seasonOf(checkInput.MatchDate)
+	in := PlayerInput{TeamID: checkHome.ID, Season: season, Name: "Conformidad Jugador", ShirtNumber: 10,
+		Position: "forward", DateOfBirth: "2000-01-31", Nationality: "ES"}
+	player, err := s.CreatePlayer(ctx, in)
+	if err != nil {
+		return fmt.Errorf("CreatePlayer: %w", err)
+	}
+	defer s.DeletePlayer(context.WithoutCancel(ctx), checkHome.ID, player.ID)
+	if player.ID <= 0 || player.Season != season || player.DateOfBirth != in.DateOfBirth || player.Nationality != in.Nationality {
+		return fmt.Errorf("CreatePlayer retornó %+v", player)
+	}
+	if _, err := s.CreatePlayer(ctx, in); !errors.Is(err, ErrShirtNumberTaken) {
+		return fmt.Errorf("CreatePlayer con un dorsal repetido retornó %v, se esperaba ErrShirtNumberTaken", err)
+	}
+	// El mismo dorsal en otra temporada es otro jugador
+	other := in
+	other.Season = seasonOf(checkInput.MatchDate.AddDate(1, 0, 0))
+	next, err := s.CreatePlayer(ctx, other)
+	if err != nil {
+		return fmt.Errorf("CreatePlayer en otra temporada: %w", err)
+	}
+	defer s.DeletePlayer(context.WithoutCancel(ctx), checkHome.ID, next.ID)
+
+	squad, err := s.ListPlayers(ctx, checkHome.ID, season)
+	if err != nil {
+		return fmt.Errorf("ListPlayers: %w", err)
+	}
+	if len(squad) != 1 || squad[0].ID != player.ID {
+		return fmt.Errorf("ListPlayers retornó %+v, se esperaba solo el jugador %d", squad, player.ID)
+	}
+	if _, err := s.ListPlayers(ctx, -1, season); !errors.Is(err, ErrTeamNotFound) {
+		return fmt.Errorf("ListPlayers de un equipo inexistente retornó %v, se esperaba ErrTeamNotFound", err)
+	}
+	if _, err := s.GetPlayer(ctx, checkAway.ID, player.ID); !errors.Is(err, ErrPlayerNotFound) {
+		return fmt.Errorf("GetPlayer con otro equipo retornó %v, se esperaba ErrPlayerNotFound", err)
+	}
+
+	return withMatch(ctx, s, func(m Match) error {
+		goal, scored, err := s.AddMatchEvent(ctx, MatchEventInput{MatchID: m.ID, Type: eventGoal, PlayerID: player.ID})
+		if err != nil {
+			return fmt.Errorf("AddMatchEvent: %w", err)
+		}
+		if goal.Team != sideHome || goal.PlayerID != player.ID || goal.Player != player.Name || scored.HomeScore != 1 {
+			return fmt.Errorf("AddMatchEvent retornó %+v y %+v", goal, scored)
+		}
+		invalid := map[string]MatchEventInput{
+			"del otro equipo":   {MatchID: m.ID, Type: eventYellowCard, Team: sideAway, PlayerID: player.ID},
+			"de otra temporada": {MatchID: m.ID, Type: eventYellowCard, PlayerID: next.ID},
+			"inexistente":       {MatchID: m.ID, Type: eventYellowCard, PlayerID: -1},
+		}
+		for name, event := range invalid {
+			want := ErrPlayerNotInMatch
+			if event.PlayerID == -1 {
+				want = ErrUnknownPlayer
+			}
+			if _, _, err := s.AddMatchEvent(ctx, event); !errors.Is(err, want) {
+				return fmt.Errorf("AddMatchEvent con un jugador %s retornó %v, se esperaba %v", name, err, want)
+			}
+		}
+
+		renamed := in
+		renamed.Name = "Conformidad Renombrado"
+		if _, err := s.UpdatePlayer(ctx, player.ID, renamed); err != nil {
+			return fmt.Errorf("UpdatePlayer: %w", err)
+		}
+		got, err := s.GetPlayer(ctx, checkHome.ID, player.ID)
+		if err != nil {
+			return fmt.Errorf("GetPlayer: %w", err)
+		}
+		if got.Name != renamed.Name || got.Stats.Goals != 1 || got.Stats.YellowCards != 0 {
+			return fmt.Errorf("GetPlayer retornó %+v", got)
+		}
+		events, err := s.ListMatchEvents(ctx, m.ID)
+		if err != nil {
+			return fmt.Errorf("ListMatchEvents: %w", err)
+		}
+		if len(events) != 1 || events[0].Player != renamed.Name {
+			return fmt.Errorf("el cambio de nombre no se copió en los eventos: %+v", events)
+		}
+		if err := s.DeletePlayer(ctx, checkHome.ID, player.ID); !errors.Is(err, ErrPlayerInUse) {
+			return fmt.Errorf("DeletePlayer de un jugador con eventos retornó %v, se esperaba ErrPlayerInUse", err)
+		}
+
+		if _, _, err := s.VoidMatchEvent(ctx, MatchEventVoid{MatchID: m.ID, EventID: goal.ID, Reason: "fuera de juego"}); err != nil {
+			return fmt.Errorf("VoidMatchEvent: %w", err)
+		}
+		got, err = s.GetPlayer(ctx, checkHome.ID, player.ID)
+		if err != nil {
+			return fmt.Errorf("GetPlayer: %w", err)
+		}
+		if got.Stats.Goals != 0 {
+			return fmt.Errorf("un gol anulado sigue en las estadísticas del jugador: %+v", got.Stats)
+		}
+		return nil
+	})
+}
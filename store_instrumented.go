@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// instrumentedStore envuelve un MatchStore y mide cuánto tarda cada llamada
+// para reportarlo en el log de la petición y en las métricas
+type instrumentedStore struct {
+	next    MatchStore
+	metrics *metrics
+}
+
+func newInstrumentedStore(next MatchStore, m *metrics) *instrumentedStore {
+	return &instrumentedStore{next: next, metrics: m}
+}
+
+// observe se difiere al inicio de cada método; err apunta al resultado
+// nombrado para conocer el error una vez que la llamada retorna
+func (s *instrumentedStore) observe(ctx context.Context, operation string, start time.Time, err *error) {
+	observeDB(ctx, start)
+	s.metrics.observeQuery(operation, start, *err)
+}
+
+func (s *instrumentedStore) ListMatches(ctx context.Context, q MatchQuery) (page MatchPage, err error) {
+	defer s.observe(ctx, "ListMatches", time.Now(), &err)
+	return s.next.ListMatches(ctx, q)
+}
+
+func (s *instrumentedStore) SearchMatches(ctx context.Context, q MatchSearch) (hits []SearchHit, err error) {
+	defer s.observe(ctx, "SearchMatches", time.Now(), &err)
+	return s.next.SearchMatches(ctx, q)
+}
+
+func (s *instrumentedStore) ListTeams(ctx context.Context) (teams []Team, err error) {
+	defer s.observe(ctx, "ListTeams", time.Now(), &err)
+	return s.next.ListTeams(ctx)
+}
+
+func (s *instrumentedStore) GetTeam(ctx context.Context, id int) (t Team, err error) {
+	defer s.observe(ctx, "GetTeam", time.Now(), &err)
+	return s.next.GetTeam(ctx, id)
+}
+
+func (s *instrumentedStore) FindTeam(ctx context.Context, names []string) (t Team, err error) {
+	defer s.observe(ctx, "FindTeam", time.Now(), &err)
+	return s.next.FindTeam(ctx, names)
+}
+
+func (s *instrumentedStore) CreateTeam(ctx context.Context, in TeamInput) (t Team, err error) {
+	defer s.observe(ctx, "CreateTeam", time.Now(), &err)
+	return s.next.CreateTeam(ctx, in)
+}
+
+func (s *instrumentedStore) UpdateTeam(ctx context.Context, id int, in TeamInput) (t Team, err error) {
+	defer s.observe(ctx, "UpdateTeam", time.Now(), &err)
+	return s.next.UpdateTeam(ctx, id, in)
+}
+
+func (s *instrumentedStore) DeleteTeam(ctx context.Context, id int) (err error) {
+	defer s.observe(ctx, "DeleteTeam", time.Now(), &err)
+	return s.next.DeleteTeam(ctx, id)
+}
+
+func (s *instrumentedStore) ListPlayers(ctx context.Context, teamID int, season string) (players []Player, err error) {
+	defer s.observe(ctx, "ListPlayers", time.Now(), &err)
+	return s.next.ListPlayers(ctx, teamID, season)
+}
+
+func (s *instrumentedStore) GetPlayer(ctx context.Context, teamID, id int) (p Player, err error) {
+	defer s.observe(ctx, "GetPlayer", time.Now(), &err)
+	return s.next.GetPlayer(ctx, teamID, id)
+}
+
+func (s *instrumentedStore) CreatePlayer(ctx context.Context, in PlayerInput) (p Player, err error) {
+	defer s.observe(ctx, "CreatePlayer", time.Now(), &err)
+	return s.next.CreatePlayer(ctx, in)
+}
+
+func (s *instrumentedStore) UpdatePlayer(ctx context.Context, id int, in PlayerInput) (p Player, err error) {
+	defer s.observe(ctx, "UpdatePlayer", time.Now(), &err)
+	return s.next.UpdatePlayer(ctx, id, in)
+}
+
+func (s *instrumentedStore) DeletePlayer(ctx context.Context, teamID, id int) (err error) {
+	defer s.observe(ctx, "DeletePlayer", time.Now(), &err)
+	return s.next.DeletePlayer(ctx, teamID, id)
+}
+
+func (s *instrumentedStore) GetMatch(ctx context.Context, id int) (m Match, err error) {
+	defer s.observe(ctx, "GetMatch", time.Now(), &err)
+	return s.next.GetMatch(ctx, id)
+}
+
+func (s *instrumentedStore) CreateMatch(ctx context.Context, in MatchInput) (m Match, err error) {
+	defer s.observe(ctx, "CreateMatch", time.Now(), &err)
+	return s.next.CreateMatch(ctx, in)
+}
+
+func (s *instrumentedStore) CreateMatches(ctx context.Context, in []MatchInput) (created []Match, err error) {
+	defer s.observe(ctx, "CreateMatches", time.Now(), &err)
+	return s.next.CreateMatches(ctx, in)
+}
+
+func (s *instrumentedStore) UpdateMatch(ctx context.Context, id int, in MatchInput) (m Match, err error) {
+	defer s.observe(ctx, "UpdateMatch", time.Now(), &err)
+	return s.next.UpdateMatch(ctx, id, in)
+}
+
+func (s *instrumentedStore) ReplaceMatch(ctx context.Context, m Match) (saved Match, err error) {
+	defer s.observe(ctx, "ReplaceMatch", time.Now(), &err)
+	return s.next.ReplaceMatch(ctx, m)
+}
+
+func (s *instrumentedStore) CorrectMatch(ctx context.Context, m Match, reason string) (saved Match, err error) {
+	defer s.observe(ctx, "CorrectMatch", time.Now(), &err)
+	return s.next.CorrectMatch(ctx, m, reason)
+}
+
+func (s *instrumentedStore) DeleteMatch(ctx context.Context, id, version int) (err error) {
+	defer s.observe(ctx, "DeleteMatch", time.Now(), &err)
+	return s.next.DeleteMatch(ctx, id, version)
+}
+
+func (s *instrumentedStore) AddMatchEvent(ctx context.Context, in MatchEventInput) (e MatchEvent, m Match, err error) {
+	defer s.observe(ctx, "AddMatchEvent", time.Now(), &err)
+	return s.next.AddMatchEvent(ctx, in)
+}
+
+func (s *instrumentedStore) ListMatchEvents(ctx context.Context, matchID int) (events []MatchEvent, err error) {
+	defer s.observe(ctx, "ListMatchEvents", time.Now(), &err)
+	return s.next.ListMatchEvents(ctx, matchID)
+}
+
+func (s *instrumentedStore) VoidMatchEvent(ctx context.Context, v MatchEventVoid) (e MatchEvent, m Match, err error) {
+	defer s.observe(ctx, "VoidMatchEvent", time.Now(), &err)
+	return s.next.VoidMatchEvent(ctx, v)
+}
+
+func (s *instrumentedStore) ReserveIdempotencyKey(ctx context.Context, rec IdempotencyRecord, since time.Time) (stored IdempotencyRecord, reserved bool, err error) {
+	defer s.observe(ctx, "ReserveIdempotencyKey", time.Now(), &err)
+	return s.next.ReserveIdempotencyKey(ctx, rec, since)
+}
+
+func (s *instrumentedStore) CompleteIdempotencyKey(ctx context.Context, rec IdempotencyRecord) (err error) {
+	defer s.observe(ctx, "CompleteIdempotencyKey", time.Now(), &err)
+	return s.next.CompleteIdempotencyKey(ctx, rec)
+}
+
+func (s *instrumentedStore) ReleaseIdempotencyKey(ctx context.Context, key string) (err error) {
+	defer s.observe(ctx, "ReleaseIdempotencyKey", time.Now(), &err)
+	return s.next.ReleaseIdempotencyKey(ctx, key)
+}
+
+func (s *instrumentedStore) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (purged int, err error) {
+	defer s.observe(ctx, "PurgeIdempotencyKeys", time.Now(), &err)
+	return s.next.PurgeIdempotencyKeys(ctx, before)
+}
+
+func (s *instrumentedStore) Ping(ctx context.Context) (err error) {
+	defer s.observe(ctx, "Ping", time.Now(), &err)
+	return s.next.Ping(ctx)
+}
+
+func (s *instrumentedStore) SchemaVersion(ctx context.Context) (version int, err error) {
+	defer s.observe(ctx, "SchemaVersion", time.Now(), &err)
+	return s.next.SchemaVersion(ctx)
+}
+
+func (s *instrumentedStore) Close() {
+	s.next.Close()
+}
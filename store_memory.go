@@ -0,0 +1,809 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryStore implementa MatchStore en memoria, útil para desarrollo local
+// y pruebas sin PostgreSQL. Los datos se pierden al detener el proceso.
+type memoryStore struct {
+	mu           sync.RWMutex
+	matches      map[int]Match
+	nextID       int
+	idempotency  map[string]IdempotencyRecord
+	events       map[int][]MatchEvent // por id de partido
+	nextEventID  int
+	teams        map[int]Team
+	nextTeamID   int
+	players      map[int]Player // sin Stats, que se calculan al leer
+	nextPlayerID int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		matches:      make(map[int]Match),
+		nextID:       1,
+		idempotency:  make(map[string]IdempotencyRecord),
+		events:       make(map[int][]MatchEvent),
+		nextEventID:  1,
+		teams:        make(map[int]Team),
+		nextTeamID:   1,
+		players:      make(map[int]Player),
+		nextPlayerID: 1,
+	}
+}
+
+func (s *memoryStore) ListMatches(ctx context.Context, q MatchQuery) (MatchPage, error) {
+	if err := ctx.Err(); err != nil {
+		return MatchPage{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []Match
+	for _, m := range s.matches {
+		if filterMatch(q, m) {
+			matches = append(matches, m)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matchBefore(q, matches[i], matches[j]) })
+
+	page := MatchPage{Total: len(matches)}
+	if q.After != nil {
+		after := Match{ID: q.After.ID, MatchDate: q.After.MatchDate}
+		i := sort.Search(len(matches), func(i int) bool { return matchBefore(q, after, matches[i]) })
+		matches = matches[i:]
+	}
+	matches = matches[min(q.Offset, len(matches)):]
+	if q.Limit > 0 && len(matches) > q.Limit {
+		matches = matches[:q.Limit]
+	}
+	page.Matches = matches
+	return page, nil
+}
+
+// filterMatch indica si m cumple los filtros de q
+func filterMatch(q MatchQuery, m Match) bool {
+	contains := func(team, text string) bool {
+		return strings.Contains(strings.ToLower(team), strings.ToLower(text))
+	}
+	switch {
+	case q.Team != "" && !contains(m.HomeTeam, q.Team) && !contains(m.AwayTeam, q.Team):
+		return false
+	case q.TeamID != 0 && m.HomeTeamID != q.TeamID && m.AwayTeamID != q.TeamID:
+		return false
+	case q.HomeTeam != "" && !contains(m.HomeTeam, q.HomeTeam):
+		return false
+	case q.AwayTeam != "" && !contains(m.AwayTeam, q.AwayTeam):
+		return false
+	case !q.From.IsZero() && m.MatchDate.Before(q.From):
+		return false
+	case !q.To.IsZero() && m.MatchDate.After(q.To):
+		return false
+	}
+	return true
+}
+
+// matchBefore indica si a va antes que b según el orden de q
+func matchBefore(q MatchQuery, a, b Match) bool {
+	if q.Desc {
+		a, b = b, a
+	}
+	if q.Sort == sortByDate && !a.MatchDate.Equal(b.MatchDate) {
+		return a.MatchDate.Before(b.MatchDate)
+	}
+	return a.ID < b.ID
+}
+
+func (s *memoryStore) SearchMatches(ctx context.Context, q MatchSearch) ([]SearchHit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var hits []SearchHit
+	for _, m := range s.matches {
+		home, away := normalizeTeam(m.HomeTeam), normalizeTeam(m.AwayTeam)
+		var score float64
+		for _, term := range q.Terms {
+			score = max(score, teamScore(term, home), teamScore(term, away))
+		}
+		if score >= q.MinScore {
+			hits = append(hits, SearchHit{Match: m, Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.Match.MatchDate.Equal(b.Match.MatchDate) {
+			return a.Match.MatchDate.After(b.Match.MatchDate)
+		}
+		return a.Match.ID < b.Match.ID
+	})
+	if q.Limit > 0 && len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	return hits, nil
+}
+
+func (s *memoryStore) GetMatch(ctx context.Context, id int) (Match, error) {
+	if err := ctx.Err(); err != nil {
+		return Match{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m, ok := s.matches[id]
+	if !ok {
+		return Match{}, ErrMatchNotFound
+	}
+	return m, nil
+}
+
+func (s *memoryStore) CreateMatch(ctx context.Context, in MatchInput) (Match, error) {
+	if err := ctx.Err(); err != nil {
+		return Match{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.newMatch(in)
+	if err != nil {
+		return Match{}, err
+	}
+	s.matches[m.ID] = m
+	s.nextID++
+	return m, nil
+}
+
+func (s *memoryStore) CreateMatches(ctx context.Context, in []MatchInput) ([]Match, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Los equipos se verifican antes de crear ninguno
+	for _, input := range in {
+		if _, _, err := s.teamNames(input.HomeTeamID, input.AwayTeamID); err != nil {
+			return nil, err
+		}
+	}
+	created := make([]Match, len(in))
+	for i, input := range in {
+		m, _ := s.newMatch(input)
+		s.matches[m.ID] = m
+		s.nextID++
+		created[i] = m
+	}
+	return created, nil
+}
+
+// newMatch arma el partido que se creará con el siguiente id
+func (s *memoryStore) newMatch(in MatchInput) (Match, error) {
+	home, away, err := s.teamNames(in.HomeTeamID, in.AwayTeamID)
+	if err != nil {
+		return Match{}, err
+	}
+	return Match{ID: s.nextID, HomeTeamID: in.HomeTeamID, HomeTeam: home, AwayTeamID: in.AwayTeamID, AwayTeam: away,
+		MatchDate: in.MatchDate, Kickoff: in.Kickoff, Version: 1, UpdatedAt: memoryNow()}, nil
+}
+
+// teamNames retorna los nombres de los equipos o ErrUnknownTeam si alguno
+// no existe
+func (s *memoryStore) teamNames(homeID, awayID int) (home, away string, err error) {
+	h, okHome := s.teams[homeID]
+	a, okAway := s.teams[awayID]
+	if !okHome || !okAway {
+		return "", "", ErrUnknownTeam
+	}
+	return h.Name, a.Name, nil
+}
+
+func (s *memoryStore) UpdateMatch(ctx context.Context, id int, in MatchInput) (Match, error) {
+	return s.updated(ctx, id, in.Version, func(m *Match) error {
+		home, away, err := s.teamNames(in.HomeTeamID, in.AwayTeamID)
+		if err != nil {
+			return err
+		}
+		m.HomeTeamID, m.HomeTeam = in.HomeTeamID, home
+		m.AwayTeamID, m.AwayTeam = in.AwayTeamID, away
+		if in.Kickoff != nil || !in.MatchDate.Equal(m.MatchDate) {
+			m.Kickoff = in.Kickoff
+		}
+		m.MatchDate = in.MatchDate
+		return nil
+	})
+}
+
+func (s *memoryStore) ReplaceMatch(ctx context.Context, m Match) (Match, error) {
+	return s.updated(ctx, m.ID, m.Version, func(stored *Match) error {
+		var err error
+		if m.HomeTeam, m.AwayTeam, err = s.teamNames(m.HomeTeamID, m.AwayTeamID); err != nil {
+			return err
+		}
+		m.Version, m.UpdatedAt = stored.Version, stored.UpdatedAt
+		*stored = m
+		return nil
+	})
+}
+
+func (s *memoryStore) CorrectMatch(ctx context.Context, m Match, reason string) (Match, error) {
+	return s.updated(ctx, m.ID, m.Version, func(stored *Match) error {
+		var err error
+		if m.HomeTeam, m.AwayTeam, err = s.teamNames(m.HomeTeamID, m.AwayTeamID); err != nil {
+			return err
+		}
+		events := s.events[m.ID]
+		now := memoryNow()
+		for _, c := range counterCorrections(*stored, m) {
+			for range c.Count {
+				events = append(events, MatchEvent{ID: s.nextEventID, MatchID: m.ID, Type: c.Type, Team: c.Team, CreatedAt: now})
+				s.nextEventID++
+			}
+			for range -c.Count {
+				if i := latestEvent(events, c.Type, c.Team); i >= 0 {
+					events[i].VoidedAt, events[i].VoidReason = &now, reason
+					continue
+				}
+				events = append(events, MatchEvent{ID: s.nextEventID, MatchID: m.ID, Type: c.Type, Team: c.Team,
+					CreatedAt: now, VoidedAt: &now, VoidReason: reason})
+				s.nextEventID++
+			}
+		}
+		s.events[m.ID] = events
+		m.Version, m.UpdatedAt = stored.Version, stored.UpdatedAt
+		*stored = m
+		return nil
+	})
+}
+
+func (s *memoryStore) DeleteMatch(ctx context.Context, id, version int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.matches[id]
+	if !ok {
+		return ErrMatchNotFound
+	}
+	if version != 0 && m.Version != version {
+		return ErrVersionMismatch
+	}
+	delete(s.matches, id)
+	delete(s.events, id)
+	return nil
+}
+
+func (s *memoryStore) AddMatchEvent(ctx context.Context, in MatchEventInput) (MatchEvent, Match, error) {
+	if err := ctx.Err(); err != nil {
+		return MatchEvent{}, Match{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.matches[in.MatchID]
+	if !ok {
+		return MatchEvent{}, Match{}, ErrMatchNotFound
+	}
+	if in.Type == eventExtraTime && m.ExtraTime >= maxExtraTime {
+		return MatchEvent{}, Match{}, ErrExtraTimeLimit
+	}
+	if in.PlayerID != 0 {
+		p, ok := s.players[in.PlayerID]
+		if !ok {
+			return MatchEvent{}, Match{}, ErrUnknownPlayer
+		}
+		if err := in.attributeTo(m, p); err != nil {
+			return MatchEvent{}, Match{}, err
+		}
+	}
+	if counters := eventCounters(&m, in.Type, in.Team); len(counters) > 0 {
+		for _, c := range counters {
+			*c.value++
+		}
+		m.Version++
+		m.UpdatedAt = memoryNow()
+		s.matches[m.ID] = m
+	}
+
+	e := MatchEvent{ID: s.nextEventID, MatchID: m.ID, Type: in.Type, AddedTime: in.AddedTime,
+		Team: in.Team, Player: in.Player, PlayerID: in.PlayerID, CreatedAt: memoryNow()}
+	if in.Minute != nil {
+		minute := *in.Minute
+		e.Minute = &minute
+	}
+	s.events[m.ID] = append(s.events[m.ID], e)
+	s.nextEventID++
+	return e, m, nil
+}
+
+func (s *memoryStore) ListMatchEvents(ctx context.Context, matchID int) ([]MatchEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.matches[matchID]; !ok {
+		return nil, ErrMatchNotFound
+	}
+	return append([]MatchEvent{}, s.events[matchID]...), nil
+}
+
+func (s *memoryStore) VoidMatchEvent(ctx context.Context, v MatchEventVoid) (MatchEvent, Match, error) {
+	if err := ctx.Err(); err != nil {
+		return MatchEvent{}, Match{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.matches[v.MatchID]
+	if !ok {
+		return MatchEvent{}, Match{}, ErrMatchNotFound
+	}
+	events := s.events[m.ID]
+	index := latestEvent(events, v.Type, v.Team)
+	if v.EventID != 0 {
+		index = slices.IndexFunc(events, func(e MatchEvent) bool { return e.ID == v.EventID })
+	}
+	e := MatchEvent{ID: s.nextEventID, MatchID: m.ID, Type: v.Type, Team: v.Team, CreatedAt: memoryNow()}
+	switch {
+	case index >= 0:
+		e = events[index]
+	case v.EventID != 0:
+		return MatchEvent{}, Match{}, ErrEventNotFound
+	}
+	if e.VoidedAt != nil {
+		return MatchEvent{}, Match{}, ErrEventAlreadyVoided
+	}
+
+	counters := eventCounters(&m, e.Type, e.Team)
+	for _, c := range counters {
+		if *c.value == 0 {
+			return MatchEvent{}, Match{}, ErrCounterBelowZero
+		}
+	}
+	if e.Type == eventGoal && e.Team == "" && m.unattributedGoals() == 0 {
+		return MatchEvent{}, Match{}, ErrCounterBelowZero
+	}
+	if len(counters) > 0 {
+		for _, c := range counters {
+			*c.value--
+		}
+		m.Version++
+		m.UpdatedAt = memoryNow()
+		s.matches[m.ID] = m
+	}
+
+	now := memoryNow()
+	e.VoidedAt, e.VoidReason = &now, v.Reason
+	if index >= 0 {
+		events[index] = e
+	} else {
+		s.events[m.ID] = append(events, e)
+		s.nextEventID++
+	}
+	return e, m, nil
+}
+
+// latestEvent retorna el índice del último evento vigente de eventType en
+// events, o -1 si no hay ninguno. Sin team vale el de cualquier equipo,
+// salvo para los goles, donde indica un gol sin equipo.
+func latestEvent(events []MatchEvent, eventType, team string) int {
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		if e.VoidedAt == nil && e.Type == eventType && (e.Team == team || team == "" && eventType != eventGoal) {
+			return i
+		}
+	}
+	return -1
+}
+
+// updated es como update pero retorna el partido resultante
+func (s *memoryStore) updated(ctx context.Context, id, version int, fn func(m *Match) error) (Match, error) {
+	var result Match
+	err := s.update(ctx, id, version, func(m *Match) error {
+		if err := fn(m); err != nil {
+			return err
+		}
+		result = *m
+		return nil
+	})
+	return result, err
+}
+
+// update aplica fn sobre el partido indicado bajo el lock de escritura y
+// aumenta su versión y su fecha de modificación. Con version distinta de cero
+// verifica que sea la actual. Si fn retorna un error el partido no cambia.
+func (s *memoryStore) update(ctx context.Context, id, version int, fn func(m *Match) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.matches[id]
+	if !ok {
+		return ErrMatchNotFound
+	}
+	if version != 0 && m.Version != version {
+		return ErrVersionMismatch
+	}
+	m.Version++
+	m.UpdatedAt = memoryNow()
+	if err := fn(&m); err != nil {
+		return err
+	}
+	s.matches[m.ID] = m
+	return nil
+}
+
+func (s *memoryStore) ListTeams(ctx context.Context) ([]Team, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teams := []Team{}
+	for _, t := range s.teams {
+		teams = append(teams, t)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		a, b := normalizeTeam(teams[i].Name), normalizeTeam(teams[j].Name)
+		if a != b {
+			return a < b
+		}
+		return teams[i].ID < teams[j].ID
+	})
+	return teams, nil
+}
+
+func (s *memoryStore) GetTeam(ctx context.Context, id int) (Team, error) {
+	if err := ctx.Err(); err != nil {
+		return Team{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.teams[id]
+	if !ok {
+		return Team{}, ErrTeamNotFound
+	}
+	return t, nil
+}
+
+func (s *memoryStore) FindTeam(ctx context.Context, names []string) (Team, error) {
+	if err := ctx.Err(); err != nil {
+		return Team{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	found, best := Team{}, len(names)
+	for _, t := range s.teams {
+		for i, name := range names {
+			if name != normalizeTeam(t.Name) && name != normalizeTeam(t.ShortName) && name != normalizeTeam(t.Code) {
+				continue
+			}
+			if i < best || i == best && t.ID < found.ID {
+				found, best = t, i
+			}
+			break
+		}
+	}
+	if best == len(names) {
+		return Team{}, ErrTeamNotFound
+	}
+	return found, nil
+}
+
+func (s *memoryStore) CreateTeam(ctx context.Context, in TeamInput) (Team, error) {
+	if err := ctx.Err(); err != nil {
+		return Team{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.teamConflict(0, in) {
+		return Team{}, ErrTeamConflict
+	}
+	t := newTeam(s.nextTeamID, in)
+	s.teams[t.ID] = t
+	s.nextTeamID++
+	return t, nil
+}
+
+func (s *memoryStore) UpdateTeam(ctx context.Context, id int, in TeamInput) (Team, error) {
+	if err := ctx.Err(); err != nil {
+		return Team{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[id]; !ok {
+		return Team{}, ErrTeamNotFound
+	}
+	if s.teamConflict(id, in) {
+		return Team{}, ErrTeamConflict
+	}
+	t := newTeam(id, in)
+	s.teams[id] = t
+	for _, m := range s.matches {
+		renamed := false
+		if m.HomeTeamID == id && m.HomeTeam != t.Name {
+			m.HomeTeam, renamed = t.Name, true
+		}
+		if m.AwayTeamID == id && m.AwayTeam != t.Name {
+			m.AwayTeam, renamed = t.Name, true
+		}
+		if renamed {
+			m.Version++
+			m.UpdatedAt = memoryNow()
+			s.matches[m.ID] = m
+		}
+	}
+	return t, nil
+}
+
+func (s *memoryStore) DeleteTeam(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[id]; !ok {
+		return ErrTeamNotFound
+	}
+	for _, m := range s.matches {
+		if m.HomeTeamID == id || m.AwayTeamID == id {
+			return ErrTeamInUse
+		}
+	}
+	delete(s.teams, id)
+	for _, p := range s.players {
+		if p.TeamID == id {
+			delete(s.players, p.ID)
+		}
+	}
+	return nil
+}
+
+// teamConflict indica si otro equipo distinto de id tiene el nombre o el
+// código de in
+func (s *memoryStore) teamConflict(id int, in TeamInput) bool {
+	name := normalizeTeam(in.Name)
+	for _, t := range s.teams {
+		if t.ID != id && (normalizeTeam(t.Name) == name || in.Code != "" && t.Code == in.Code) {
+			return true
+		}
+	}
+	return false
+}
+
+func newTeam(id int, in TeamInput) Team {
+	return Team{ID: id, Name: in.Name, ShortName: in.ShortName, Code: in.Code, Founded: in.Founded,
+		Colors: append([]string{}, in.Colors...), CrestURL: in.CrestURL}
+}
+
+func (s *memoryStore) ListPlayers(ctx context.Context, teamID int, season string) ([]Player, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.teams[teamID]; !ok {
+		return nil, ErrTeamNotFound
+	}
+	players := []Player{}
+	for _, p := range s.players {
+		if p.TeamID == teamID && p.Season == season {
+			players = append(players, s.withStats(p))
+		}
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].ShirtNumber < players[j].ShirtNumber })
+	return players, nil
+}
+
+func (s *memoryStore) GetPlayer(ctx context.Context, teamID, id int) (Player, error) {
+	if err := ctx.Err(); err != nil {
+		return Player{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.players[id]
+	if !ok || p.TeamID != teamID {
+		return Player{}, ErrPlayerNotFound
+	}
+	return s.withStats(p), nil
+}
+
+func (s *memoryStore) CreatePlayer(ctx context.Context, in PlayerInput) (Player, error) {
+	if err := ctx.Err(); err != nil {
+		return Player{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[in.TeamID]; !ok {
+		return Player{}, ErrTeamNotFound
+	}
+	if s.shirtNumberTaken(0, in) {
+		return Player{}, ErrShirtNumberTaken
+	}
+	p := newPlayer(s.nextPlayerID, in)
+	s.players[p.ID] = p
+	s.nextPlayerID++
+	return p, nil
+}
+
+func (s *memoryStore) UpdatePlayer(ctx context.Context, id int, in PlayerInput) (Player, error) {
+	if err := ctx.Err(); err != nil {
+		return Player{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.players[id]; !ok || p.TeamID != in.TeamID {
+		return Player{}, ErrPlayerNotFound
+	}
+	if s.shirtNumberTaken(id, in) {
+		return Player{}, ErrShirtNumberTaken
+	}
+	p := newPlayer(id, in)
+	s.players[id] = p
+	for _, events := range s.events {
+		for i := range events {
+			if events[i].PlayerID == id {
+				events[i].Player = p.Name
+			}
+		}
+	}
+	return s.withStats(p), nil
+}
+
+func (s *memoryStore) DeletePlayer(ctx context.Context, teamID, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.players[id]; !ok || p.TeamID != teamID {
+		return ErrPlayerNotFound
+	}
+	for _, events := range s.events {
+		for _, e := range events {
+			if e.PlayerID == id {
+				return ErrPlayerInUse
+			}
+		}
+	}
+	delete(s.players, id)
+	return nil
+}
+
+// shirtNumberTaken indica si otro jugador distinto de id usa el dorsal de in
+// en el mismo equipo y temporada
+func (s *memoryStore) shirtNumberTaken(id int, in PlayerInput) bool {
+	for _, p := range s.players {
+		if p.ID != id && p.TeamID == in.TeamID && p.Season == in.Season && p.ShirtNumber == in.ShirtNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// withStats completa las estadísticas de p con sus eventos vigentes
+func (s *memoryStore) withStats(p Player) Player {
+	for _, events := range s.events {
+		for _, e := range events {
+			if e.PlayerID != p.ID || e.VoidedAt != nil {
+				continue
+			}
+			switch e.Type {
+			case eventGoal:
+				p.Stats.Goals++
+			case eventYellowCard:
+				p.Stats.YellowCards++
+			case eventRedCard:
+				p.Stats.RedCards++
+			}
+		}
+	}
+	return p
+}
+
+func newPlayer(id int, in PlayerInput) Player {
+	return Player{ID: id, TeamID: in.TeamID, Season: in.Season, Name: in.Name, ShirtNumber: in.ShirtNumber,
+		Position: in.Position, DateOfBirth: in.DateOfBirth, Nationality: in.Nationality}
+}
+
+func (s *memoryStore) ReserveIdempotencyKey(ctx context.Context, rec IdempotencyRecord, since time.Time) (IdempotencyRecord, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.idempotency[rec.Key]; ok && !stored.CreatedAt.Before(since) {
+		return stored, false, nil
+	}
+	rec.Status, rec.CreatedAt = 0, memoryNow()
+	s.idempotency[rec.Key] = rec
+	return rec, true, nil
+}
+
+func (s *memoryStore) CompleteIdempotencyKey(ctx context.Context, rec IdempotencyRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.idempotency[rec.Key]; ok {
+		stored.Status, stored.Header, stored.Body = rec.Status, rec.Header, rec.Body
+		s.idempotency[rec.Key] = stored
+	}
+	return nil
+}
+
+func (s *memoryStore) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.idempotency[key]; ok && stored.Status == 0 {
+		delete(s.idempotency, key)
+	}
+	return nil
+}
+
+func (s *memoryStore) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for key, stored := range s.idempotency {
+		if stored.CreatedAt.Before(before) {
+			delete(s.idempotency, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// memoryNow es la hora de modificación con la precisión de timestamptz
+func memoryNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func (s *memoryStore) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// SchemaVersion siempre coincide: el almacenamiento en memoria no tiene esquema
+func (s *memoryStore) SchemaVersion(ctx context.Context) (int, error) {
+	return expectedSchemaVersion, ctx.Err()
+}
+
+func (s *memoryStore) Close() {}
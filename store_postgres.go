@@ -0,0 +1,938 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresStore implementa MatchStore sobre un pool de PostgreSQL
+type postgresStore struct {
+	pool *pgxpool.Pool
+}
+
+func newPostgresStore(pool *pgxpool.Pool) *postgresStore {
+	return &postgresStore{pool: pool}
+}
+
+// matchColumns son las columnas que se leen de cada partido, en el orden
+// que espera matchFields
+const matchColumns = `id, home_team_id, home_team, away_team_id, away_team, match_date,
+            goals, home_score, away_score, yellow_cards, red_cards, extra_time, kickoff, version, updated_at`
+
+// matchFields retorna los destinos de Scan para matchColumns
+func matchFields(m *Match) []any {
+	return []any{&m.ID, &m.HomeTeamID, &m.HomeTeam, &m.AwayTeamID, &m.AwayTeam, &m.MatchDate,
+		&m.Goals, &m.HomeScore, &m.AwayScore, &m.YellowCards, &m.RedCards, &m.ExtraTime, &m.Kickoff, &m.Version, &m.UpdatedAt}
+}
+
+func (s *postgresStore) ListMatches(ctx context.Context, q MatchQuery) (MatchPage, error) {
+	where, args := matchFilterSQL(q)
+
+	var page MatchPage
+	err := s.pool.QueryRow(ctx, "SELECT count(*) FROM matches"+where.String(), args...).Scan(&page.Total)
+	if err != nil {
+		return MatchPage{}, err
+	}
+
+	direction, comparison := "ASC", ">"
+	if q.Desc {
+		direction, comparison = "DESC", "<"
+	}
+	orderBy := "id " + direction
+	if q.Sort == sortByDate {
+		orderBy = "match_date " + direction + ", id " + direction
+	}
+	if q.After != nil {
+		if q.Sort == sortByDate {
+			args = append(args, q.After.MatchDate, q.After.ID)
+			where.add(fmt.Sprintf("(match_date, id) %s ($%d, $%d)", comparison, len(args)-1, len(args)))
+		} else {
+			args = append(args, q.After.ID)
+			where.add(fmt.Sprintf("id %s $%d", comparison, len(args)))
+		}
+	}
+	args = append(args, q.Offset)
+	limit := "ALL"
+	if q.Limit > 0 {
+		limit = strconv.Itoa(q.Limit)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT `+matchColumns+`
+        FROM matches`+where.String()+`
+        ORDER BY `+orderBy+`
+        LIMIT `+limit+fmt.Sprintf(" OFFSET $%d", len(args)),
+		args...)
+	if err != nil {
+		return MatchPage{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m Match
+		if err := rows.Scan(matchFields(&m)...); err != nil {
+			return MatchPage{}, err
+		}
+		page.Matches = append(page.Matches, m)
+	}
+	return page, rows.Err()
+}
+
+// whereClause acumula condiciones unidas con AND
+type whereClause []string
+
+func (w *whereClause) add(cond string) {
+	*w = append(*w, cond)
+}
+
+func (w whereClause) String() string {
+	if len(w) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w, " AND ")
+}
+
+// matchFilterSQL traduce los filtros de q a condiciones con parámetros
+// numerados; no incluye el cursor para que sirva también al conteo
+func matchFilterSQL(q MatchQuery) (whereClause, []any) {
+	var where whereClause
+	var args []any
+	param := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if q.Team != "" {
+		p := param(likePattern(q.Team))
+		where.add(fmt.Sprintf("(home_team ILIKE %[1]s OR away_team ILIKE %[1]s)", p))
+	}
+	if q.TeamID != 0 {
+		p := param(q.TeamID)
+		where.add(fmt.Sprintf("(home_team_id = %[1]s OR away_team_id = %[1]s)", p))
+	}
+	if q.HomeTeam != "" {
+		where.add("home_team ILIKE " + param(likePattern(q.HomeTeam)))
+	}
+	if q.AwayTeam != "" {
+		where.add("away_team ILIKE " + param(likePattern(q.AwayTeam)))
+	}
+	if !q.From.IsZero() {
+		where.add("match_date >= " + param(q.From))
+	}
+	if !q.To.IsZero() {
+		where.add("match_date <= " + param(q.To))
+	}
+	return where, args
+}
+
+// likePattern busca text en cualquier posición escapando los comodines de LIKE
+func likePattern(text string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
+}
+
+// SearchMatches puntúa cada partido con el mejor término: 1 si alguna palabra
+// de un equipo empieza con el término y si no la similitud por trigramas en
+// ambos sentidos, para que "atletico de madrid" encuentre "Atlético" y viceversa
+func (s *postgresStore) SearchMatches(ctx context.Context, q MatchSearch) ([]SearchHit, error) {
+	rows, err := s.pool.Query(ctx, `
+        SELECT `+matchColumns+`, s.score
+        FROM matches m
+        CROSS JOIN LATERAL (SELECT
+            immutable_unaccent(lower(m.home_team)) AS home,
+            immutable_unaccent(lower(m.away_team)) AS away) n
+        CROSS JOIN LATERAL (
+            SELECT max(GREATEST(
+                word_similarity(t.term, n.home), word_similarity(n.home, t.term),
+                word_similarity(t.term, n.away), word_similarity(n.away, t.term),
+                CASE WHEN starts_with(n.home, t.term) OR starts_with(n.away, t.term)
+                    OR position(' ' || t.term IN n.home) > 0 OR position(' ' || t.term IN n.away) > 0
+                THEN 1 ELSE 0 END
+            )) AS score
+            FROM unnest($1::text[]) AS t(term)
+        ) s
+        WHERE s.score >= $2
+        ORDER BY s.score DESC, match_date DESC, id
+        LIMIT $3`,
+		q.Terms, q.MinScore, q.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []SearchHit
+	for rows.Next() {
+		var h SearchHit
+		if err := rows.Scan(append(matchFields(&h.Match), &h.Score)...); err != nil {
+			return nil, err
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+func (s *postgresStore) GetMatch(ctx context.Context, id int) (Match, error) {
+	var m Match
+	err := s.pool.QueryRow(ctx, `
+        SELECT `+matchColumns+`
+        FROM matches WHERE id = $1`,
+		id,
+	).Scan(matchFields(&m)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Match{}, ErrMatchNotFound
+	}
+	return m, err
+}
+
+// insertMatchSQL crea un partido con los nombres actuales de sus equipos y no
+// inserta nada si alguno no existe. FOR SHARE impide que los equipos se
+// renombren o eliminen hasta que termine la transacción.
+const insertMatchSQL = `
+        INSERT INTO matches (home_team_id, home_team, away_team_id, away_team, match_date, kickoff)
+        SELECT h.id, h.name, a.id, a.name, $3::date, $4::timestamptz
+        FROM teams h, teams a
+        WHERE h.id = $1 AND a.id = $2
+        FOR SHARE
+        RETURNING ` + matchColumns
+
+func (s *postgresStore) CreateMatch(ctx context.Context, in MatchInput) (Match, error) {
+	var m Match
+	err := s.pool.QueryRow(ctx, insertMatchSQL, in.HomeTeamID, in.AwayTeamID, in.MatchDate, in.Kickoff).Scan(matchFields(&m)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Match{}, ErrUnknownTeam
+	}
+	return m, err
+}
+
+func (s *postgresStore) CreateMatches(ctx context.Context, in []MatchInput) ([]Match, error) {
+	created := make([]Match, len(in))
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		for i, input := range in {
+			err := tx.QueryRow(ctx, insertMatchSQL, input.HomeTeamID, input.AwayTeamID, input.MatchDate, input.Kickoff).Scan(matchFields(&created[i])...)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUnknownTeam
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// matchTeamsSQL son las tablas con los equipos $1 y $2 para los UPDATE de un
+// partido, bloqueados como en insertMatchSQL. Las columnas tienen alias para
+// no confundirse con las de matches.
+const matchTeamsSQL = `
+        (SELECT id AS home_id, name AS home_name FROM teams WHERE id = $1 FOR SHARE) h,
+        (SELECT id AS away_id, name AS away_name FROM teams WHERE id = $2 FOR SHARE) a`
+
+// UpdateMatch conserva la hora de inicio si no se indica otra y la fecha no
+// cambia; match_date en el CASE es el valor anterior a la actualización
+func (s *postgresStore) UpdateMatch(ctx context.Context, id int, in MatchInput) (Match, error) {
+	var m Match
+	err := s.pool.QueryRow(ctx, `
+        UPDATE matches SET home_team_id = home_id, home_team = home_name,
+            away_team_id = away_id, away_team = away_name, match_date = $3,
+            kickoff = CASE WHEN $6::timestamptz IS NOT NULL THEN $6
+                WHEN match_date = $3 THEN kickoff END,
+            version = version + 1, updated_at = now()
+        FROM `+matchTeamsSQL+`
+        WHERE id = $4 AND ($5 = 0 OR version = $5)
+        RETURNING `+matchColumns,
+		in.HomeTeamID, in.AwayTeamID, in.MatchDate, id, in.Version, in.Kickoff,
+	).Scan(matchFields(&m)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Match{}, s.missingStaleOrUnknown(ctx, id, in.HomeTeamID, in.AwayTeamID)
+	}
+	return m, err
+}
+
+// replaceMatchSQL guarda los campos editables de un partido con los
+// parámetros de replaceMatchArgs
+const replaceMatchSQL = `
+        UPDATE matches SET home_team_id = home_id, home_team = home_name,
+            away_team_id = away_id, away_team = away_name, match_date = $3,
+            goals = $4, home_score = $5, away_score = $6,
+            yellow_cards = $7, red_cards = $8, extra_time = $9, kickoff = $12,
+            version = version + 1, updated_at = now()
+        FROM ` + matchTeamsSQL + `
+        WHERE id = $10 AND ($11 = 0 OR version = $11)
+        RETURNING ` + matchColumns
+
+func replaceMatchArgs(m Match) []any {
+	return []any{m.HomeTeamID, m.AwayTeamID, m.MatchDate,
+		m.Goals, m.HomeScore, m.AwayScore, m.YellowCards, m.RedCards, m.ExtraTime, m.ID, m.Version, m.Kickoff}
+}
+
+func (s *postgresStore) ReplaceMatch(ctx context.Context, m Match) (Match, error) {
+	var saved Match
+	err := s.pool.QueryRow(ctx, replaceMatchSQL, replaceMatchArgs(m)...).Scan(matchFields(&saved)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Match{}, s.missingStaleOrUnknown(ctx, m.ID, m.HomeTeamID, m.AwayTeamID)
+	}
+	return saved, err
+}
+
+func (s *postgresStore) CorrectMatch(ctx context.Context, m Match, reason string) (Match, error) {
+	var saved Match
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// El partido queda bloqueado hasta guardar los contadores, para que
+		// los eventos registrados en paralelo no cambien la diferencia
+		var current Match
+		err := tx.QueryRow(ctx, "SELECT "+matchColumns+" FROM matches WHERE id = $1 AND ($2 = 0 OR version = $2) FOR UPDATE",
+			m.ID, m.Version).Scan(matchFields(&current)...)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return s.missingOrStale(ctx, m.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, c := range counterCorrections(current, m) {
+			if err := correctEvents(ctx, tx, m.ID, c, reason); err != nil {
+				return err
+			}
+		}
+		err = tx.QueryRow(ctx, replaceMatchSQL, replaceMatchArgs(m)...).Scan(matchFields(&saved)...)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnknownTeam
+		}
+		return err
+	})
+	if err != nil {
+		return Match{}, err
+	}
+	return saved, nil
+}
+
+// correctEvents registra en la cronología de matchID los eventos de c. Los
+// que se anulan y no tienen un evento vigente son contadores anteriores a la
+// cronología y se agregan ya anulados.
+func correctEvents(ctx context.Context, tx pgx.Tx, matchID int, c counterCorrection, reason string) error {
+	if c.Count > 0 {
+		_, err := tx.Exec(ctx, `
+            INSERT INTO match_events (match_id, type, team)
+            SELECT $1, $2, NULLIF($3, '') FROM generate_series(1, $4)`,
+			matchID, c.Type, c.Team, c.Count)
+		return err
+	}
+	result, err := tx.Exec(ctx, `
+        UPDATE match_events SET voided_at = now(), void_reason = $5
+        WHERE id IN (
+            SELECT id
+            FROM match_events
+            WHERE match_id = $1 AND type = $2 AND (COALESCE(team, '') = $3 OR $3 = '' AND $2 <> 'goal') AND voided_at IS NULL
+            ORDER BY id DESC
+            LIMIT $4)`,
+		matchID, c.Type, c.Team, -c.Count, reason)
+	if err != nil {
+		return err
+	}
+	if missing := -c.Count - int(result.RowsAffected()); missing > 0 {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO match_events (match_id, type, team, voided_at, void_reason)
+            SELECT $1, $2, NULLIF($3, ''), now(), $4 FROM generate_series(1, $5)`,
+			matchID, c.Type, c.Team, reason, missing)
+	}
+	return err
+}
+
+func (s *postgresStore) DeleteMatch(ctx context.Context, id, version int) error {
+	result, err := s.pool.Exec(ctx, "DELETE FROM matches WHERE id = $1 AND ($2 = 0 OR version = $2)", id, version)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return s.missingOrStale(ctx, id)
+	}
+	return nil
+}
+
+// missingOrStale explica por qué una escritura condicionada a la versión no
+// afectó filas: el partido no existe o cambió de versión
+func (s *postgresStore) missingOrStale(ctx context.Context, id int) error {
+	var exists bool
+	if err := s.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM matches WHERE id = $1)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return ErrVersionMismatch
+	}
+	return ErrMatchNotFound
+}
+
+// missingStaleOrUnknown es missingOrStale para las escrituras que también
+// asignan los equipos: la tercera causa es que alguno no exista
+func (s *postgresStore) missingStaleOrUnknown(ctx context.Context, id, homeID, awayID int) error {
+	var exists, teams bool
+	err := s.pool.QueryRow(ctx, `
+        SELECT EXISTS (SELECT 1 FROM matches WHERE id = $1),
+            EXISTS (SELECT 1 FROM teams WHERE id = $2) AND EXISTS (SELECT 1 FROM teams WHERE id = $3)`,
+		id, homeID, awayID,
+	).Scan(&exists, &teams)
+	switch {
+	case err != nil:
+		return err
+	case !exists:
+		return ErrMatchNotFound
+	case !teams:
+		return ErrUnknownTeam
+	default:
+		return ErrVersionMismatch
+	}
+}
+
+// counterColumns son las columnas de matches de cada contador de eventCounters
+var counterColumns = map[string]string{
+	"goals":       "goals",
+	"homeScore":   "home_score",
+	"awayScore":   "away_score",
+	"yellowCards": "yellow_cards",
+	"redCards":    "red_cards",
+	"extraTime":   "extra_time",
+}
+
+// eventCounterSQL retorna la asignación que suma delta a los contadores del
+// evento y la condición que evita dejarlos negativos; set es vacío si el
+// evento no cambia ningún contador
+func eventCounterSQL(eventType, team string, delta int) (set, nonNegative string) {
+	op, n := "+", delta
+	if delta < 0 {
+		op, n = "-", -delta
+	}
+	var sets, conds []string
+	for _, c := range eventCounters(&Match{}, eventType, team) {
+		column := counterColumns[c.name]
+		sets = append(sets, fmt.Sprintf("%s = %s %s %d", column, column, op, n))
+		conds = append(conds, fmt.Sprintf("%s %s %d >= 0", column, op, n))
+	}
+	return strings.Join(sets, ", "), strings.Join(conds, " AND ")
+}
+
+// matchEventColumns son las columnas que se leen de cada evento, en el orden
+// que espera matchEventFields
+const matchEventColumns = `id, match_id, type, minute, added_time, COALESCE(team, ''), COALESCE(player, ''),
+            COALESCE(player_id, 0), created_at, voided_at, COALESCE(void_reason, '')`
+
+// matchEventFields retorna los destinos de Scan para matchEventColumns
+func matchEventFields(e *MatchEvent) []any {
+	return []any{&e.ID, &e.MatchID, &e.Type, &e.Minute, &e.AddedTime, &e.Team, &e.Player,
+		&e.PlayerID, &e.CreatedAt, &e.VoidedAt, &e.VoidReason}
+}
+
+func (s *postgresStore) AddMatchEvent(ctx context.Context, in MatchEventInput) (MatchEvent, Match, error) {
+	e := MatchEvent{MatchID: in.MatchID, Type: in.Type, Minute: in.Minute, AddedTime: in.AddedTime, PlayerID: in.PlayerID}
+	var m Match
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if in.PlayerID != 0 {
+			if err := attributeEvent(ctx, tx, &in); err != nil {
+				return err
+			}
+		}
+		e.Team, e.Player = in.Team, in.Player
+		// El UPDATE (o FOR UPDATE si el evento no cambia contadores) bloquea
+		// la fila hasta insertar el evento
+		query := "SELECT " + matchColumns + " FROM matches WHERE id = $1 FOR UPDATE"
+		if set, _ := eventCounterSQL(in.Type, in.Team, 1); set != "" {
+			// El tope se verifica en la misma sentencia para que dos eventos
+			// concurrentes no lo superen
+			var limit string
+			if in.Type == eventExtraTime {
+				limit = fmt.Sprintf(" AND extra_time < %d", maxExtraTime)
+			}
+			query = fmt.Sprintf("UPDATE matches SET %s, version = version + 1, updated_at = now() WHERE id = $1%s RETURNING %s", set, limit, matchColumns)
+		}
+		err := tx.QueryRow(ctx, query, in.MatchID).Scan(matchFields(&m)...)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return missingOrLimited(ctx, tx, in)
+		}
+		if err != nil {
+			return err
+		}
+		return tx.QueryRow(ctx, `
+            INSERT INTO match_events (match_id, type, minute, added_time, team, player, player_id)
+            VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, 0))
+            RETURNING id, created_at`,
+			in.MatchID, in.Type, in.Minute, in.AddedTime, in.Team, in.Player, in.PlayerID,
+		).Scan(&e.ID, &e.CreatedAt)
+	})
+	if err != nil {
+		return MatchEvent{}, Match{}, err
+	}
+	return e, m, nil
+}
+
+// attributeEvent completa el evento con el equipo y el nombre de su jugador.
+// Bloquea el partido y el jugador para que no cambien hasta registrar el evento.
+func attributeEvent(ctx context.Context, tx pgx.Tx, in *MatchEventInput) error {
+	var m Match
+	err := tx.QueryRow(ctx, "SELECT "+matchColumns+" FROM matches WHERE id = $1 FOR UPDATE", in.MatchID).Scan(matchFields(&m)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrMatchNotFound
+	}
+	if err != nil {
+		return err
+	}
+	var p Player
+	err = tx.QueryRow(ctx, "SELECT team_id, season, name FROM players WHERE id = $1 FOR SHARE", in.PlayerID).Scan(&p.TeamID, &p.Season, &p.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrUnknownPlayer
+	}
+	if err != nil {
+		return err
+	}
+	return in.attributeTo(m, p)
+}
+
+// missingOrLimited explica por qué un evento no actualizó el partido: no
+// existe o ya llegó al tope de tiempo extra
+func missingOrLimited(ctx context.Context, tx pgx.Tx, in MatchEventInput) error {
+	if in.Type != eventExtraTime {
+		return ErrMatchNotFound
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM matches WHERE id = $1)", in.MatchID).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return ErrExtraTimeLimit
+	}
+	return ErrMatchNotFound
+}
+
+func (s *postgresStore) ListMatchEvents(ctx context.Context, matchID int) ([]MatchEvent, error) {
+	rows, err := s.pool.Query(ctx, `
+        SELECT `+matchEventColumns+`
+        FROM match_events
+        WHERE match_id = $1
+        ORDER BY id`, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []MatchEvent{}
+	for rows.Next() {
+		var e MatchEvent
+		if err := rows.Scan(matchEventFields(&e)...); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		if _, err := s.GetMatch(ctx, matchID); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+func (s *postgresStore) VoidMatchEvent(ctx context.Context, v MatchEventVoid) (MatchEvent, Match, error) {
+	var e MatchEvent
+	var m Match
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// Bloquear el partido primero ordena las anulaciones concurrentes
+		err := tx.QueryRow(ctx, "SELECT "+matchColumns+" FROM matches WHERE id = $1 FOR UPDATE", v.MatchID).Scan(matchFields(&m)...)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMatchNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if v.EventID != 0 {
+			err = tx.QueryRow(ctx, "SELECT "+matchEventColumns+" FROM match_events WHERE id = $1 AND match_id = $2",
+				v.EventID, v.MatchID).Scan(matchEventFields(&e)...)
+		} else {
+			err = tx.QueryRow(ctx, `
+                SELECT `+matchEventColumns+`
+                FROM match_events
+                WHERE match_id = $1 AND type = $2 AND (COALESCE(team, '') = $3 OR $3 = '' AND $2 <> 'goal') AND voided_at IS NULL
+                ORDER BY id DESC
+                LIMIT 1`,
+				v.MatchID, v.Type, v.Team).Scan(matchEventFields(&e)...)
+		}
+		switch {
+		case errors.Is(err, pgx.ErrNoRows) && v.EventID != 0:
+			return ErrEventNotFound
+		case errors.Is(err, pgx.ErrNoRows):
+			e = MatchEvent{MatchID: v.MatchID, Type: v.Type, Team: v.Team}
+		case err != nil:
+			return err
+		case e.VoidedAt != nil:
+			return ErrEventAlreadyVoided
+		}
+
+		if set, nonNegative := eventCounterSQL(e.Type, e.Team, -1); set != "" {
+			if e.Type == eventGoal && e.Team == "" {
+				nonNegative += " AND goals > home_score + away_score"
+			}
+			err = tx.QueryRow(ctx,
+				fmt.Sprintf("UPDATE matches SET %s, version = version + 1, updated_at = now() WHERE id = $1 AND %s RETURNING %s", set, nonNegative, matchColumns),
+				v.MatchID,
+			).Scan(matchFields(&m)...)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrCounterBelowZero
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if e.ID == 0 {
+			return tx.QueryRow(ctx, `
+                INSERT INTO match_events (match_id, type, team, voided_at, void_reason)
+                VALUES ($1, $2, NULLIF($3, ''), now(), $4)
+                RETURNING `+matchEventColumns,
+				v.MatchID, v.Type, v.Team, v.Reason,
+			).Scan(matchEventFields(&e)...)
+		}
+		return tx.QueryRow(ctx,
+			"UPDATE match_events SET voided_at = now(), void_reason = $1 WHERE id = $2 RETURNING "+matchEventColumns,
+			v.Reason, e.ID,
+		).Scan(matchEventFields(&e)...)
+	})
+	if err != nil {
+		return MatchEvent{}, Match{}, err
+	}
+	return e, m, nil
+}
+
+// teamColumns son las columnas que se leen de cada equipo, en el orden que
+// espera teamFields
+const teamColumns = `id, name, COALESCE(short_name, ''), COALESCE(code, ''), COALESCE(founded, 0),
+            colors, COALESCE(crest_url, '')`
+
+// teamFields retorna los destinos de Scan para teamColumns
+func teamFields(t *Team) []any {
+	return []any{&t.ID, &t.Name, &t.ShortName, &t.Code, &t.Founded, &t.Colors, &t.CrestURL}
+}
+
+// teamArgs retorna los parámetros $1 a $6 de las escrituras de teams; los
+// campos opcionales vacíos se guardan como NULL con NULLIF
+func teamArgs(in TeamInput) []any {
+	colors := in.Colors
+	if colors == nil {
+		colors = []string{}
+	}
+	return []any{in.Name, in.ShortName, in.Code, in.Founded, colors, in.CrestURL}
+}
+
+// constraintError traduce las violaciones de restricciones al error del
+// almacenamiento: unique para los índices únicos y foreignKey para las
+// referencias entre tablas
+func constraintError(err, unique, foreignKey error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return unique
+		case "23503": // foreign_key_violation
+			return foreignKey
+		}
+	}
+	return err
+}
+
+func (s *postgresStore) ListTeams(ctx context.Context) ([]Team, error) {
+	rows, err := s.pool.Query(ctx, "SELECT "+teamColumns+" FROM teams ORDER BY immutable_unaccent(lower(name)), id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := []Team{}
+	for rows.Next() {
+		var t Team
+		if err := rows.Scan(teamFields(&t)...); err != nil {
+			return nil, err
+		}
+		teams = append(teams, t)
+	}
+	return teams, rows.Err()
+}
+
+func (s *postgresStore) GetTeam(ctx context.Context, id int) (Team, error) {
+	var t Team
+	err := s.pool.QueryRow(ctx, "SELECT "+teamColumns+" FROM teams WHERE id = $1", id).Scan(teamFields(&t)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Team{}, ErrTeamNotFound
+	}
+	return t, err
+}
+
+// FindTeam compara como normalizeTeam con immutable_unaccent(lower(...)); los
+// nombres de los equipos ya se guardan sin espacios repetidos
+func (s *postgresStore) FindTeam(ctx context.Context, names []string) (Team, error) {
+	var t Team
+	err := s.pool.QueryRow(ctx, `
+        SELECT `+teamColumns+`
+        FROM teams
+        CROSS JOIN LATERAL (SELECT LEAST(
+            array_position($1::text[], immutable_unaccent(lower(name))),
+            array_position($1::text[], immutable_unaccent(lower(short_name))),
+            array_position($1::text[], lower(code))) AS rank) r
+        WHERE r.rank IS NOT NULL
+        ORDER BY r.rank, id
+        LIMIT 1`,
+		names,
+	).Scan(teamFields(&t)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Team{}, ErrTeamNotFound
+	}
+	return t, err
+}
+
+func (s *postgresStore) CreateTeam(ctx context.Context, in TeamInput) (Team, error) {
+	var t Team
+	err := s.pool.QueryRow(ctx, `
+        INSERT INTO teams (name, short_name, code, founded, colors, crest_url)
+        VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, 0), $5, NULLIF($6, ''))
+        RETURNING `+teamColumns,
+		teamArgs(in)...,
+	).Scan(teamFields(&t)...)
+	if err != nil {
+		return Team{}, constraintError(err, ErrTeamConflict, err)
+	}
+	return t, nil
+}
+
+// UpdateTeam copia el nombre en los partidos del equipo en la misma
+// transacción; solo cambian de versión los partidos cuyo nombre cambió
+func (s *postgresStore) UpdateTeam(ctx context.Context, id int, in TeamInput) (Team, error) {
+	var t Team
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+            UPDATE teams SET name = $1, short_name = NULLIF($2, ''), code = NULLIF($3, ''),
+                founded = NULLIF($4, 0), colors = $5, crest_url = NULLIF($6, '')
+            WHERE id = $7
+            RETURNING `+teamColumns,
+			append(teamArgs(in), id)...,
+		).Scan(teamFields(&t)...)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTeamNotFound
+		}
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+            UPDATE matches SET
+                home_team = CASE WHEN home_team_id = $1 THEN $2 ELSE home_team END,
+                away_team = CASE WHEN away_team_id = $1 THEN $2 ELSE away_team END,
+                version = version + 1, updated_at = now()
+            WHERE home_team_id = $1 AND home_team <> $2 OR away_team_id = $1 AND away_team <> $2`,
+			id, t.Name)
+		return err
+	})
+	if err != nil {
+		return Team{}, constraintError(err, ErrTeamConflict, err)
+	}
+	return t, nil
+}
+
+func (s *postgresStore) DeleteTeam(ctx context.Context, id int) error {
+	result, err := s.pool.Exec(ctx, "DELETE FROM teams WHERE id = $1", id)
+	if err != nil {
+		return constraintError(err, err, ErrTeamInUse)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+// playerSelect lee los jugadores de source, la tabla players o una CTE con
+// sus columnas, junto con las estadísticas de sus eventos vigentes
+func playerSelect(source string) string {
+	return `
+        SELECT p.id, p.team_id, p.season, p.name, p.shirt_number, p.position,
+            COALESCE(to_char(p.date_of_birth, 'YYYY-MM-DD'), ''), COALESCE(p.nationality, ''),
+            s.goals, s.yellow_cards, s.red_cards
+        FROM ` + source + ` p
+        CROSS JOIN LATERAL (SELECT
+            count(*) FILTER (WHERE e.type = 'goal') AS goals,
+            count(*) FILTER (WHERE e.type = 'yellow_card') AS yellow_cards,
+            count(*) FILTER (WHERE e.type = 'red_card') AS red_cards
+            FROM match_events e
+            WHERE e.player_id = p.id AND e.voided_at IS NULL) s`
+}
+
+// playerFields retorna los destinos de Scan para playerSelect
+func playerFields(p *Player) []any {
+	return []any{&p.ID, &p.TeamID, &p.Season, &p.Name, &p.ShirtNumber, &p.Position, &p.DateOfBirth, &p.Nationality,
+		&p.Stats.Goals, &p.Stats.YellowCards, &p.Stats.RedCards}
+}
+
+func (s *postgresStore) ListPlayers(ctx context.Context, teamID int, season string) ([]Player, error) {
+	rows, err := s.pool.Query(ctx, playerSelect("players")+`
+        WHERE p.team_id = $1 AND p.season = $2
+        ORDER BY p.shirt_number`, teamID, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	players := []Player{}
+	for rows.Next() {
+		var p Player
+		if err := rows.Scan(playerFields(&p)...); err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(players) == 0 {
+		if _, err := s.GetTeam(ctx, teamID); err != nil {
+			return nil, err
+		}
+	}
+	return players, nil
+}
+
+func (s *postgresStore) GetPlayer(ctx context.Context, teamID, id int) (Player, error) {
+	var p Player
+	err := s.pool.QueryRow(ctx, playerSelect("players")+" WHERE p.id = $1 AND p.team_id = $2", id, teamID).Scan(playerFields(&p)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Player{}, ErrPlayerNotFound
+	}
+	return p, err
+}
+
+// CreatePlayer retorna ErrTeamNotFound si la referencia a teams falla
+func (s *postgresStore) CreatePlayer(ctx context.Context, in PlayerInput) (Player, error) {
+	var p Player
+	err := s.pool.QueryRow(ctx, `
+        WITH p AS (
+            INSERT INTO players (team_id, season, name, shirt_number, position, date_of_birth, nationality)
+            VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::date, NULLIF($7, ''))
+            RETURNING *
+        )`+playerSelect("p"),
+		in.TeamID, in.Season, in.Name, in.ShirtNumber, in.Position, in.DateOfBirth, in.Nationality,
+	).Scan(playerFields(&p)...)
+	if err != nil {
+		return Player{}, constraintError(err, ErrShirtNumberTaken, ErrTeamNotFound)
+	}
+	return p, nil
+}
+
+// UpdatePlayer copia el nombre en los eventos del jugador en la misma
+// transacción
+func (s *postgresStore) UpdatePlayer(ctx context.Context, id int, in PlayerInput) (Player, error) {
+	var p Player
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+            WITH p AS (
+                UPDATE players SET season = $3, name = $4, shirt_number = $5, position = $6,
+                    date_of_birth = NULLIF($7, '')::date, nationality = NULLIF($8, '')
+                WHERE id = $1 AND team_id = $2
+                RETURNING *
+            )`+playerSelect("p"),
+			id, in.TeamID, in.Season, in.Name, in.ShirtNumber, in.Position, in.DateOfBirth, in.Nationality,
+		).Scan(playerFields(&p)...)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPlayerNotFound
+		}
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, "UPDATE match_events SET player = $2 WHERE player_id = $1 AND player <> $2", id, p.Name)
+		return err
+	})
+	if err != nil {
+		return Player{}, constraintError(err, ErrShirtNumberTaken, err)
+	}
+	return p, nil
+}
+
+func (s *postgresStore) DeletePlayer(ctx context.Context, teamID, id int) error {
+	result, err := s.pool.Exec(ctx, "DELETE FROM players WHERE id = $1 AND team_id = $2", id, teamID)
+	if err != nil {
+		return constraintError(err, err, ErrPlayerInUse)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrPlayerNotFound
+	}
+	return nil
+}
+
+// ReserveIdempotencyKey elimina antes rec.Key si venció, para que la
+// inserción sea la que decida entre peticiones concurrentes con la misma
+// clave. Las demás claves vencidas las elimina PurgeIdempotencyKeys.
+func (s *postgresStore) ReserveIdempotencyKey(ctx context.Context, rec IdempotencyRecord, since time.Time) (IdempotencyRecord, bool, error) {
+	if _, err := s.pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE key = $1 AND created_at < $2", rec.Key, since); err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+	err := s.pool.QueryRow(ctx, `
+        INSERT INTO idempotency_keys (key, fingerprint) VALUES ($1, $2)
+        ON CONFLICT (key) DO NOTHING
+        RETURNING created_at`,
+		rec.Key, rec.Fingerprint,
+	).Scan(&rec.CreatedAt)
+	if err == nil {
+		return rec, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return IdempotencyRecord{}, false, err
+	}
+
+	var stored IdempotencyRecord
+	err = s.pool.QueryRow(ctx, `
+        SELECT key, fingerprint, status, headers, body, created_at
+        FROM idempotency_keys WHERE key = $1`,
+		rec.Key,
+	).Scan(&stored.Key, &stored.Fingerprint, &stored.Status, &stored.Header, &stored.Body, &stored.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// La otra petición liberó la clave entre ambas consultas; se informa
+		// como en curso y el cliente puede reintentar
+		return IdempotencyRecord{Key: rec.Key, Fingerprint: rec.Fingerprint}, false, nil
+	}
+	return stored, false, err
+}
+
+func (s *postgresStore) CompleteIdempotencyKey(ctx context.Context, rec IdempotencyRecord) error {
+	_, err := s.pool.Exec(ctx,
+		"UPDATE idempotency_keys SET status = $2, headers = $3, body = $4 WHERE key = $1",
+		rec.Key, rec.Status, rec.Header, rec.Body)
+	return err
+}
+
+func (s *postgresStore) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	_, err := s.pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE key = $1 AND status = 0", key)
+	return err
+}
+
+func (s *postgresStore) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE created_at < $1", before)
+	return int(result.RowsAffected()), err
+}
+
+func (s *postgresStore) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
+func (s *postgresStore) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := s.pool.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
+
+func (s *postgresStore) Close() {
+	s.pool.Close()
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Team es un equipo. Los partidos lo referencian por id y repiten su nombre.
+// @Description Equipo con sus datos de club
+type Team struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name" example:"FC Barcelona"`
+	ShortName string   `json:"shortName,omitempty" example:"Barça"`
+	Code      string   `json:"code,omitempty" example:"FCB"`
+	Founded   int      `json:"founded,omitempty" example:"1899"`
+	Colors    []string `json:"colors" example:"#A50044,#004D98"`
+	CrestURL  string   `json:"crestUrl,omitempty" example:"https://example.com/escudos/fcb.svg"`
+}
+
+// TeamInput contiene los datos editables de un equipo
+type TeamInput struct {
+	Name      string
+	ShortName string
+	Code      string
+	Founded   int
+	Colors    []string
+	CrestURL  string
+}
+
+// teamRequest es el cuerpo para crear o reemplazar un equipo
+type teamRequest struct {
+	Name      string   `json:"name" binding:"required,max=255"`
+	ShortName string   `json:"shortName" binding:"max=50"`
+	Code      string   `json:"code" binding:"omitempty,len=3,alpha,uppercase"`
+	Founded   int      `json:"founded" binding:"omitempty,min=1850,max=2100"`
+	Colors    []string `json:"colors" binding:"max=4,dive,hexcolor"`
+	CrestURL  string   `json:"crestUrl" binding:"omitempty,url,max=2048"`
+}
+
+// bindTeamRequest lee y valida el cuerpo; responde el error si no es válido.
+// Los nombres se guardan sin espacios repetidos para que coincidan con
+// normalizeTeam.
+func bindTeamRequest(c *gin.Context) (TeamInput, bool) {
+	var req teamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return TeamInput{}, false
+	}
+	in := TeamInput{
+		Name:      strings.Join(strings.Fields(req.Name), " "),
+		ShortName: strings.Join(strings.Fields(req.ShortName), " "),
+		Code:      req.Code,
+		Founded:   req.Founded,
+		Colors:    req.Colors,
+		CrestURL:  req.CrestURL,
+	}
+	if in.Name == "" {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"),
+			FieldError{Field: "name", Code: fieldRequired, Message: tr(c, "validation.required")})
+		return TeamInput{}, false
+	}
+	if in.Colors == nil {
+		in.Colors = []string{}
+	}
+	return in, true
+}
+
+// matchTeams son los equipos de un partido en el cuerpo de las rutas de v1:
+// por id o, por compatibilidad con los clientes anteriores, por nombre
+type matchTeams struct {
+	HomeTeamID int    `json:"homeTeamId" binding:"omitempty,min=1"`
+	HomeTeam   string `json:"homeTeam"`
+	AwayTeamID int    `json:"awayTeamId" binding:"omitempty,min=1"`
+	AwayTeam   string `json:"awayTeam"`
+}
+
+// teamLookupNames retorna los nombres normalizados con los que se busca un
+// equipo: el recibido, su nombre canónico en teamAliases y los demás alias del
+// mismo canónico, para que "Atleti" encuentre "Atlético de Madrid"
+func teamLookupNames(name string) []string {
+	names := expandSearchTerms(name)
+	canonical := names[len(names)-1]
+	var aliases []string
+	for alias, c := range teamAliases {
+		if c == canonical && alias != names[0] {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return append(names, aliases...)
+}
+
+// teamIDs resuelve los equipos de t; el id tiene preferencia sobre el nombre.
+// Un nombre se busca con FindTeam y teamLookupNames. Los problemas del cuerpo
+// se retornan como error de campo con el prefijo indicado: el código es
+// UNKNOWN_TEAM si un nombre no corresponde a ningún equipo. La existencia de
+// los ids la verifica el almacenamiento al guardar.
+func (a *app) teamIDs(c *gin.Context, t matchTeams, prefix string) (home, away int, fieldErr *FieldError, err error) {
+	resolve := func(id int, name, idField, nameField string) (int, *FieldError, error) {
+		if id != 0 {
+			return id, nil, nil
+		}
+		if strings.TrimSpace(name) == "" {
+			return 0, &FieldError{Field: prefix + idField, Code: fieldRequired, Message: tr(c, "field.team_required", nameField)}, nil
+		}
+		team, err := a.store.FindTeam(c.Request.Context(), teamLookupNames(name))
+		if errors.Is(err, ErrTeamNotFound) {
+			return 0, &FieldError{Field: prefix + nameField, Code: codeUnknownTeam, Message: tr(c, "field.unknown_team", name)}, nil
+		}
+		return team.ID, nil, err
+	}
+
+	if home, fieldErr, err = resolve(t.HomeTeamID, t.HomeTeam, "homeTeamId", "homeTeam"); fieldErr != nil || err != nil {
+		return 0, 0, fieldErr, err
+	}
+	if away, fieldErr, err = resolve(t.AwayTeamID, t.AwayTeam, "awayTeamId", "awayTeam"); fieldErr != nil || err != nil {
+		return 0, 0, fieldErr, err
+	}
+	if home == away {
+		return 0, 0, sameTeamError(c, prefix), nil
+	}
+	return home, away, nil, nil
+}
+
+// sameTeamError es el error de un partido con el mismo equipo como local y
+// como visitante
+func sameTeamError(c *gin.Context, prefix string) *FieldError {
+	return &FieldError{Field: prefix + "awayTeamId", Code: fieldInvalidValue, Message: tr(c, "field.same_team")}
+}
+
+// teamProblemCode es el código de la respuesta para un error de teamIDs
+func teamProblemCode(fieldErr *FieldError) string {
+	if fieldErr.Code == codeUnknownTeam {
+		return codeUnknownTeam
+	}
+	return codeValidationFailed
+}
+
+// resolveTeams resuelve los equipos del cuerpo de un partido con teamIDs;
+// responde el error y retorna false si no son válidos
+func (a *app) resolveTeams(c *gin.Context, t matchTeams) (home, away int, ok bool) {
+	home, away, fieldErr, err := a.teamIDs(c, t, "")
+	if err != nil {
+		respondStoreError(c, err)
+		return 0, 0, false
+	}
+	if fieldErr != nil {
+		code := teamProblemCode(fieldErr)
+		detail := tr(c, "detail.validation_failed")
+		if code == codeUnknownTeam {
+			detail = tr(c, "detail.unknown_team")
+		}
+		respondProblem(c, code, detail, *fieldErr)
+		return 0, 0, false
+	}
+	return home, away, true
+}
+
+// listTeams godoc
+// @Summary Listar equipos
+// @Description Retorna todos los equipos ordenados por nombre
+// @Tags teams
+// @Produce json
+// @Success 200 {array} Team
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams [get]
+func (a *app) listTeams(c *gin.Context) {
+	teams, err := a.store.ListTeams(c.Request.Context())
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, teams)
+}
+
+// getTeam godoc
+// @Summary Obtener un equipo
+// @Tags teams
+// @Produce json
+// @Param id path int true "ID del Equipo"
+// @Success 200 {object} Team
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams/{id} [get]
+func (a *app) getTeam(c *gin.Context) {
+	teamID, ok := parsePathID(c, "id")
+	if !ok {
+		return
+	}
+	team, err := a.store.GetTeam(c.Request.Context(), teamID)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, team)
+}
+
+// createTeam godoc
+// @Summary Crear un equipo
+// @Description El nombre y el código no se pueden repetir; el nombre se compara sin distinguir mayúsculas ni acentos.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param team body teamRequest true "Datos del equipo"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 201 {object} Team
+// @Header 201 {string} Location "URL del equipo creado"
+// @Failure 400 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams [post]
+func (a *app) createTeam(c *gin.Context) {
+	in, ok := bindTeamRequest(c)
+	if !ok {
+		return
+	}
+	team, err := a.store.CreateTeam(c.Request.Context(), in)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.Header("Location", "/api/teams/"+strconv.Itoa(team.ID))
+	c.IndentedJSON(http.StatusCreated, team)
+}
+
+// updateTeam godoc
+// @Summary Reemplazar los datos de un equipo
+// @Description Si cambia el nombre, se actualiza en todos los partidos del equipo y su versión aumenta.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Equipo"
+// @Param team body teamRequest true "Datos del equipo"
+// @Success 200 {object} Team
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams/{id} [put]
+func (a *app) updateTeam(c *gin.Context) {
+	teamID, ok := parsePathID(c, "id")
+	if !ok {
+		return
+	}
+	in, ok := bindTeamRequest(c)
+	if !ok {
+		return
+	}
+	team, err := a.store.UpdateTeam(c.Request.Context(), teamID, in)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, team)
+}
+
+// deleteTeam godoc
+// @Summary Eliminar un equipo
+// @Description Solo se puede eliminar un equipo que no juega ningún partido
+// @Tags teams
+// @Param id path int true "ID del Equipo"
+// @Success 204
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /teams/{id} [delete]
+func (a *app) deleteTeam(c *gin.Context) {
+	teamID, ok := parsePathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := a.store.DeleteTeam(c.Request.Context(), teamID); err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
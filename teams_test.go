@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestCreateTeam(t *testing.T) {
+	s := newTestServer(t)
+	s.team("Sevilla")
+
+	body := map[string]any{
+		"name": "  Real   Betis ", "shortName": "Betis", "code": "BET", "founded": 1907,
+		"colors": []string{"#00954C", "#FFFFFF"}, "crestUrl": "https://example.com/betis.png",
+	}
+	rec := s.do(http.MethodPost, "/api/teams", body)
+	expectStatus(t, rec, http.StatusCreated)
+	team := decode[Team](t, rec)
+	if team.Name != "Real Betis" || team.Code != "BET" || team.Founded != 1907 || len(team.Colors) != 2 {
+		t.Errorf("equipo %+v", team)
+	}
+	if got, want := rec.Header().Get("Location"), fmt.Sprintf("/api/teams/%d", team.ID); got != want {
+		t.Errorf("Location %q, se esperaba %q", got, want)
+	}
+
+	if got := decode[Team](t, s.do(http.MethodGet, fmt.Sprintf("/api/teams/%d", team.ID), nil)); got.Name != team.Name {
+		t.Errorf("GET retornó %+v, se esperaba %+v", got, team)
+	}
+	var names []string
+	for _, tm := range decode[[]Team](t, s.do(http.MethodGet, "/api/teams", nil)) {
+		names = append(names, tm.Name)
+	}
+	if want := []string{"Real Betis", "Sevilla"}; !slices.Equal(names, want) {
+		t.Errorf("equipos %v, se esperaba %v", names, want)
+	}
+}
+
+func TestTeamErrors(t *testing.T) {
+	s := newTestServer(t)
+	atletico, sevilla := s.team("Atlético de Madrid"), s.team("Sevilla")
+	if _, err := s.store.UpdateTeam(t.Context(), sevilla.ID, TeamInput{Name: "Sevilla", Code: "SEV"}); err != nil {
+		t.Fatal(err)
+	}
+	free := s.team("Getafe")
+	s.match(atletico, sevilla, "2025-04-01")
+	team := func(id int) string { return fmt.Sprintf("/api/teams/%d", id) }
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		status int
+		code   string
+		field  string
+	}{
+		{"sin nombre", http.MethodPost, "/api/teams", map[string]any{"code": "GET"}, http.StatusBadRequest, codeValidationFailed, "name"},
+		{"nombre en blanco", http.MethodPost, "/api/teams", map[string]any{"name": "   "}, http.StatusBadRequest, codeValidationFailed, "name"},
+		{"código en minúsculas", http.MethodPost, "/api/teams", map[string]any{"name": "Osasuna", "code": "osa"}, http.StatusBadRequest, codeValidationFailed, "code"},
+		{"año de fundación", http.MethodPost, "/api/teams", map[string]any{"name": "Osasuna", "founded": 1800}, http.StatusBadRequest, codeValidationFailed, "founded"},
+		{"color inválido", http.MethodPost, "/api/teams", map[string]any{"name": "Osasuna", "colors": []string{"rojo"}}, http.StatusBadRequest, codeValidationFailed, ""},
+		{"nombre repetido sin acentos", http.MethodPost, "/api/teams", map[string]any{"name": "ATLETICO DE MADRID"}, http.StatusConflict, codeTeamConflict, ""},
+		{"código repetido", http.MethodPost, "/api/teams", map[string]any{"name": "Osasuna", "code": "SEV"}, http.StatusConflict, codeTeamConflict, ""},
+		{"renombrar a un nombre existente", http.MethodPut, team(free.ID), map[string]any{"name": "sevilla"}, http.StatusConflict, codeTeamConflict, ""},
+		{"reemplazar un equipo desconocido", http.MethodPut, team(999), map[string]any{"name": "Osasuna"}, http.StatusNotFound, codeTeamNotFound, ""},
+		{"id inválido", http.MethodGet, "/api/teams/x", nil, http.StatusBadRequest, codeInvalidID, ""},
+		{"equipo desconocido", http.MethodGet, team(999), nil, http.StatusNotFound, codeTeamNotFound, ""},
+		{"eliminar un equipo con partidos", http.MethodDelete, team(atletico.ID), nil, http.StatusConflict, codeTeamInUse, ""},
+		{"eliminar un equipo desconocido", http.MethodDelete, team(999), nil, http.StatusNotFound, codeTeamNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := expectProblem(t, s.do(tt.method, tt.target, tt.body), tt.status, tt.code)
+			if tt.field != "" && (len(p.Errors) != 1 || p.Errors[0].Field != tt.field) {
+				t.Errorf("errores %+v, se esperaba el campo %s", p.Errors, tt.field)
+			}
+		})
+	}
+
+	teams, err := s.store.ListTeams(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 3 {
+		t.Errorf("%d equipos, se esperaba 3: %+v", len(teams), teams)
+	}
+}
+
+func TestUpdateTeamRenamesMatches(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Atletico"), s.team("Sevilla")
+	m := s.match(home, away, "2025-04-01")
+
+	rec := s.do(http.MethodPut, fmt.Sprintf("/api/teams/%d", home.ID), map[string]any{"name": "Atlético de Madrid", "code": "ATM"})
+	expectStatus(t, rec, http.StatusOK)
+	if got := decode[Team](t, rec); got.ID != home.ID || got.Name != "Atlético de Madrid" || got.Code != "ATM" {
+		t.Errorf("equipo %+v", got)
+	}
+
+	got, err := s.store.GetMatch(t.Context(), m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.HomeTeam != "Atlético de Madrid" || got.Version != m.Version+1 {
+		t.Errorf("partido %+v, se esperaba el nombre nuevo y la versión %d", got, m.Version+1)
+	}
+}
+
+func TestDeleteTeam(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Getafe")
+	target := fmt.Sprintf("/api/teams/%d", team.ID)
+
+	rec := s.do(http.MethodDelete, target, nil)
+	expectStatus(t, rec, http.StatusNoContent)
+	if rec.Body.Len() != 0 {
+		t.Errorf("un 204 no debe tener cuerpo: %q", rec.Body.String())
+	}
+	expectProblem(t, s.do(http.MethodGet, target, nil), http.StatusNotFound, codeTeamNotFound)
+}
+
+// TestMatchTeamByName verifica que los partidos acepten los equipos por
+// nombre o alias, como los clientes anteriores a /api/teams
+func TestMatchTeamByName(t *testing.T) {
+	s := newTestServer(t)
+	atletico, barcelona := s.team("Atlético de Madrid"), s.team("Barcelona")
+
+	rec := s.do(http.MethodPost, "/api/matches", map[string]any{"homeTeam": "Atleti", "awayTeam": "barça", "matchDate": "2025-04-01"})
+	expectStatus(t, rec, http.StatusCreated)
+	m := decode[struct {
+		HomeTeamID int    `json:"homeTeamId"`
+		HomeTeam   string `json:"homeTeam"`
+		AwayTeamID int    `json:"awayTeamId"`
+	}](t, rec)
+	if m.HomeTeamID != atletico.ID || m.AwayTeamID != barcelona.ID || m.HomeTeam != atletico.Name {
+		t.Errorf("partido %+v, se esperaba %s contra %s", m, atletico.Name, barcelona.Name)
+	}
+
+	tests := []struct {
+		name   string
+		body   map[string]any
+		status int
+		code   string
+		field  string
+	}{
+		{"nombre desconocido", map[string]any{"homeTeam": "Atlantis", "awayTeamId": barcelona.ID, "matchDate": "2025-04-01"}, http.StatusUnprocessableEntity, codeUnknownTeam, "homeTeam"},
+		{"id desconocido", map[string]any{"homeTeamId": atletico.ID, "awayTeamId": 999, "matchDate": "2025-04-01"}, http.StatusUnprocessableEntity, codeUnknownTeam, ""},
+		{"sin visitante", map[string]any{"homeTeamId": atletico.ID, "matchDate": "2025-04-01"}, http.StatusBadRequest, codeValidationFailed, "awayTeamId"},
+		{"mismo equipo por alias", map[string]any{"homeTeam": "Atleti", "awayTeamId": atletico.ID, "matchDate": "2025-04-01"}, http.StatusBadRequest, codeValidationFailed, "awayTeamId"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := expectProblem(t, s.do(http.MethodPost, "/api/matches", tt.body), tt.status, tt.code)
+			if tt.field != "" && (len(p.Errors) != 1 || p.Errors[0].Field != tt.field) {
+				t.Errorf("errores %+v, se esperaba el campo %s", p.Errors, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timeoutSettings define el tiempo máximo de cada petición. PerRoute usa
+// claves "MÉTODO /ruta" con la ruta tal como se registra en gin,
+// por ejemplo "PATCH /api/matches/:id/goals".
+type timeoutSettings struct {
+	Default  time.Duration
+	PerRoute map[string]time.Duration
+}
+
+// parseRouteTimeouts interpreta una lista separada por comas como
+// "GET /api/matches=10s,PATCH /api/matches/:id/goals=2s"
+func parseRouteTimeouts(routes string) (map[string]time.Duration, error) {
+	perRoute := map[string]time.Duration{}
+	if routes == "" {
+		return perRoute, nil
+	}
+	for _, entry := range strings.Split(routes, ",") {
+		route, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		fields := strings.Fields(route)
+		if !ok || len(fields) != 2 {
+			return nil, fmt.Errorf("entrada inválida %q, use \"MÉTODO /ruta=duración\"", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("duración inválida en %q", entry)
+		}
+		perRoute[strings.ToUpper(fields[0])+" "+fields[1]] = d
+	}
+	return perRoute, nil
+}
+
+// timeoutFor retorna el tiempo máximo para la ruta indicada
+func (s timeoutSettings) timeoutFor(method, path string) time.Duration {
+	if d, ok := s.PerRoute[method+" "+path]; ok {
+		return d
+	}
+	return s.Default
+}
+
+// requestTimeout deriva el contexto de cada petición con el tiempo máximo de
+// su ruta. El contexto también se cancela si el cliente cierra la conexión.
+func requestTimeout(s timeoutSettings) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), s.timeoutFor(c.Request.Method, c.FullPath()))
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
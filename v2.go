@@ -0,0 +1,346 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @title LaLigaTracker API v2
+// @version 2.0
+// @description Segunda versión de la API de partidos: fecha y hora de inicio por separado, estado, marcador por equipo y estadísticas agrupadas.
+// @description Comparte el almacenamiento con /api, cuyas rutas de partidos están obsoletas (ver las cabeceras Deprecation y Sunset).
+// @contact.name Soporte API
+// @contact.email soporte@sebastian_laliga.com
+// @license.name MIT
+// @host localhost:8080
+// @BasePath /api/v2
+
+// MatchV2 es un partido en la API v2. Kickoff, la hora de inicio, se
+// devuelve en UTC y se omite si no se conoce.
+// @Description Partido con su estado y estadísticas
+type MatchV2 struct {
+	ID        int        `json:"id"`
+	HomeTeam  TeamRefV2  `json:"homeTeam"`
+	AwayTeam  TeamRefV2  `json:"awayTeam"`
+	Date      string     `json:"date" example:"2025-04-01"`
+	Kickoff   *time.Time `json:"kickoff,omitempty" example:"2025-04-01T19:00:00Z"`
+	Status    string     `json:"status" enums:"scheduled,live,finished"`
+	Score     ScoreV2    `json:"score"`
+	Stats     StatsV2    `json:"stats"`
+	Version   int        `json:"version"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// TeamRefV2 es un equipo del partido; el resto de sus datos está en /api/teams/{id}
+type TeamRefV2 struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ScoreV2 es el marcador del partido. Result se omite si el partido tiene
+// goles registrados sin equipo.
+type ScoreV2 struct {
+	Home   int    `json:"home"`
+	Away   int    `json:"away"`
+	Result string `json:"result,omitempty" enums:"home,draw,away"`
+}
+
+// StatsV2 agrupa los contadores del partido; a diferencia de v1 los ceros
+// siempre se incluyen
+type StatsV2 struct {
+	Goals       int `json:"goals"`
+	YellowCards int `json:"yellowCards"`
+	RedCards    int `json:"redCards"`
+	ExtraTime   int `json:"extraTime"`
+}
+
+// MatchListV2 es una página del listado de partidos en la API v2
+type MatchListV2 struct {
+	Data  []MatchV2 `json:"data"`
+	Total int       `json:"total"`
+}
+
+// matchRequestV2 es el cuerpo para crear o reemplazar un partido en la API v2.
+// Con kickoff, date se puede omitir y se toma de la hora de inicio en su
+// propia zona horaria.
+type matchRequestV2 struct {
+	HomeTeamID int    `json:"homeTeamId" binding:"required,min=1"`
+	AwayTeamID int    `json:"awayTeamId" binding:"required,min=1"`
+	Date       string `json:"date" example:"2025-04-01"`
+	Kickoff    string `json:"kickoff" example:"2025-04-01T21:00:00+02:00"`
+}
+
+func newMatchV2(m Match, now time.Time) MatchV2 {
+	return MatchV2{
+		ID:       m.ID,
+		HomeTeam: TeamRefV2{ID: m.HomeTeamID, Name: m.HomeTeam},
+		AwayTeam: TeamRefV2{ID: m.AwayTeamID, Name: m.AwayTeam},
+		Date:     m.MatchDate.Format(time.DateOnly),
+		Kickoff:  kickoffUTC(m.Kickoff),
+		Status:   matchStatus(m.MatchDate, now),
+		Score:    ScoreV2{Home: m.HomeScore, Away: m.AwayScore, Result: m.result()},
+		Stats: StatsV2{
+			Goals:       m.Goals,
+			YellowCards: m.YellowCards,
+			RedCards:    m.RedCards,
+			ExtraTime:   m.ExtraTime,
+		},
+		Version:   m.Version,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// kickoffUTC retorna la hora de inicio en UTC, sea cual sea la zona con la
+// que la devuelva el almacenamiento
+func kickoffUTC(kickoff *time.Time) *time.Time {
+	if kickoff == nil {
+		return nil
+	}
+	utc := kickoff.UTC()
+	return &utc
+}
+
+// bindMatchRequestV2 lee y valida el cuerpo; responde el error si no es válido
+func bindMatchRequestV2(c *gin.Context) (MatchInput, bool) {
+	var req matchRequestV2
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return MatchInput{}, false
+	}
+	if req.HomeTeamID == req.AwayTeamID {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"), *sameTeamError(c, ""))
+		return MatchInput{}, false
+	}
+	in := MatchInput{HomeTeamID: req.HomeTeamID, AwayTeamID: req.AwayTeamID}
+	if req.Kickoff != "" {
+		kickoff, err := time.Parse(time.RFC3339, req.Kickoff)
+		if err != nil {
+			respondProblem(c, codeInvalidDate, tr(c, "detail.invalid_date"),
+				FieldError{Field: "kickoff", Code: codeInvalidDate, Message: tr(c, "field.kickoff_format")})
+			return MatchInput{}, false
+		}
+		in.Kickoff = &kickoff
+		if req.Date == "" {
+			req.Date = kickoff.Format(time.DateOnly)
+		}
+	}
+	if req.Date == "" {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"),
+			FieldError{Field: "date", Code: fieldRequired, Message: tr(c, "field.date_required")})
+		return MatchInput{}, false
+	}
+	var err error
+	if in.MatchDate, err = time.Parse(time.DateOnly, req.Date); err != nil {
+		respondProblem(c, codeInvalidDate, tr(c, "detail.invalid_date"),
+			FieldError{Field: "date", Code: codeInvalidDate, Message: tr(c, "field.date_format")})
+		return MatchInput{}, false
+	}
+	if in.Kickoff != nil && in.Kickoff.Format(time.DateOnly) != req.Date {
+		respondProblem(c, codeValidationFailed, tr(c, "detail.validation_failed"),
+			FieldError{Field: "date", Code: fieldInvalidValue, Message: tr(c, "field.kickoff_date")})
+		return MatchInput{}, false
+	}
+	return in, true
+}
+
+// listMatchesV2 godoc
+// @Summary Listar partidos
+// @Description Mismos filtros y paginación que GET /api/matches; la página viene en data y el total en total.
+// @Tags matches-v2
+// @Produce json
+// @Param team query string false "Equipo local o visitante (contiene, sin distinguir mayúsculas)"
+// @Param teamId query int false "ID del equipo local o visitante"
+// @Param homeTeam query string false "Equipo local"
+// @Param awayTeam query string false "Equipo visitante"
+// @Param from query string false "Fecha mínima (YYYY-MM-DD)"
+// @Param to query string false "Fecha máxima (YYYY-MM-DD)"
+// @Param status query string false "Estado del partido" Enums(scheduled, live, finished)
+// @Param sort query string false "Orden" Enums(id, -id, date, -date)
+// @Param limit query int false "Tamaño de página"
+// @Param offset query int false "Partidos a omitir"
+// @Param cursor query string false "Cursor de la cabecera Link"
+// @Param If-None-Match header string false "ETag de una respuesta anterior"
+// @Success 200 {object} MatchListV2
+// @Header 200 {string} Link "Páginas first, prev, next y last"
+// @Header 200 {string} ETag "Cambia si cambia la página"
+// @Success 304 "La página no cambió"
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches [get]
+func (a *app) listMatchesV2(c *gin.Context) {
+	now := time.Now()
+	req, fieldErr := parseListRequest(c, a.pagination, now)
+	if fieldErr != nil {
+		code := codeInvalidQuery
+		if fieldErr.Code == codeInvalidDate {
+			code = codeInvalidDate
+		}
+		respondProblem(c, code, fieldErr.Error(), *fieldErr)
+		return
+	}
+
+	q := req.query
+	q.Limit++
+	page, err := a.store.ListMatches(c.Request.Context(), q)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	hasNext := len(page.Matches) > req.query.Limit
+	if hasNext {
+		page.Matches = page.Matches[:req.query.Limit]
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(page.Total))
+	c.Header("Link", linkHeader(c.Request.URL, req, page, hasNext))
+	if notModified(c, listETag(page, hasNext), lastModified(page.Matches), a.cache.List) {
+		return
+	}
+	list := MatchListV2{Data: make([]MatchV2, len(page.Matches)), Total: page.Total}
+	for i, m := range page.Matches {
+		list.Data[i] = newMatchV2(m, now)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// getMatchV2 godoc
+// @Summary Obtener un partido
+// @Tags matches-v2
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Param If-None-Match header string false "ETag de una respuesta anterior"
+// @Success 200 {object} MatchV2
+// @Header 200 {string} ETag "Versión del partido"
+// @Success 304 "El partido no cambió"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id} [get]
+func (a *app) getMatchV2(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+	match, err := a.store.GetMatch(c.Request.Context(), matchID)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	now := time.Now()
+	if notModified(c, matchETag(match), match.UpdatedAt, a.cache.forMatch(match, now)) {
+		return
+	}
+	c.JSON(http.StatusOK, newMatchV2(match, now))
+}
+
+// createMatchV2 godoc
+// @Summary Crear un partido
+// @Description kickoff es la hora de inicio en RFC 3339 y es opcional. Si se envía, date se puede omitir
+// @Description y debe ser la fecha de kickoff en la zona horaria indicada.
+// @Tags matches-v2
+// @Accept json
+// @Produce json
+// @Param match body matchRequestV2 true "Datos del partido"
+// @Param Idempotency-Key header string false "Clave única de la operación para reintentarla sin duplicarla"
+// @Success 201 {object} MatchV2
+// @Header 201 {string} Location "URL del partido creado"
+// @Failure 400 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches [post]
+func (a *app) createMatchV2(c *gin.Context) {
+	in, ok := bindMatchRequestV2(c)
+	if !ok {
+		return
+	}
+	match, err := a.store.CreateMatch(c.Request.Context(), in)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	setMatchETag(c, match)
+	c.Header("Location", "/api/v2/matches/"+strconv.Itoa(match.ID))
+	c.JSON(http.StatusCreated, newMatchV2(match, time.Now()))
+}
+
+// replaceMatchV2 godoc
+// @Summary Reemplazar los datos de un partido
+// @Description Reemplaza equipos, fecha y hora de inicio; las estadísticas no cambian. Acepta If-Match como PUT /api/matches/{id}.
+// @Description Sin kickoff se conserva la hora de inicio anterior si la fecha no cambia y se borra si cambia.
+// @Tags matches-v2
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del Partido"
+// @Param match body matchRequestV2 true "Datos del partido"
+// @Param If-Match header string false "ETag leído del partido"
+// @Success 200 {object} MatchV2
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 412 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 428 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id} [put]
+func (a *app) replaceMatchV2(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+	in, ok := bindMatchRequestV2(c)
+	if !ok {
+		return
+	}
+	if in.Version, ok = a.expectedVersion(c, matchID); !ok {
+		return
+	}
+	match, err := a.store.UpdateMatch(c.Request.Context(), matchID, in)
+	if err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	setMatchETag(c, match)
+	c.JSON(http.StatusOK, newMatchV2(match, time.Now()))
+}
+
+// deleteMatchV2 godoc
+// @Summary Eliminar un partido
+// @Tags matches-v2
+// @Param id path int true "ID del Partido"
+// @Param If-Match header string false "ETag leído del partido"
+// @Success 204
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 412 {object} Problem
+// @Failure 428 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 503 {object} Problem
+// @Failure 504 {object} Problem
+// @Router /matches/{id} [delete]
+func (a *app) deleteMatchV2(c *gin.Context) {
+	matchID, ok := parseMatchID(c)
+	if !ok {
+		return
+	}
+	version, ok := a.expectedVersion(c, matchID)
+	if !ok {
+		return
+	}
+	if err := a.store.DeleteMatch(c.Request.Context(), matchID, version); err != nil {
+		respondStoreError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCreateMatchV2Kickoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     map[string]any
+		wantDate string
+		kickoff  string
+	}{
+		{"sin hora de inicio", map[string]any{"date": "2025-04-01"}, "2025-04-01", ""},
+		{"fecha de la hora de inicio", map[string]any{"kickoff": "2025-04-01T21:00:00+02:00"}, "2025-04-01", "2025-04-01T19:00:00Z"},
+		{"fecha local distinta de la UTC", map[string]any{"kickoff": "2025-04-02T00:30:00+02:00"}, "2025-04-02", "2025-04-01T22:30:00Z"},
+		{"fecha y hora de inicio", map[string]any{"date": "2025-04-01", "kickoff": "2025-04-01T21:00:00Z"}, "2025-04-01", "2025-04-01T21:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			home, away := s.team("Barcelona"), s.team("Real Madrid")
+			tt.body["homeTeamId"], tt.body["awayTeamId"] = home.ID, away.ID
+
+			rec := s.do(http.MethodPost, "/api/v2/matches", tt.body)
+			expectStatus(t, rec, http.StatusCreated)
+			got := decode[map[string]any](t, rec)
+			if got["date"] != tt.wantDate {
+				t.Errorf("date %v, se esperaba %s", got["date"], tt.wantDate)
+			}
+			kickoff, ok := got["kickoff"]
+			if tt.kickoff == "" && ok || tt.kickoff != "" && kickoff != tt.kickoff {
+				t.Errorf("kickoff %v, se esperaba %q", kickoff, tt.kickoff)
+			}
+
+			// v1 no publica la hora de inicio
+			v1 := decode[map[string]any](t, s.do(http.MethodGet, fmt.Sprintf("/api/matches/%v", got["id"]), nil))
+			if _, ok := v1["kickoff"]; ok {
+				t.Errorf("v1 incluye kickoff: %v", v1)
+			}
+		})
+	}
+}
+
+func TestCreateMatchV2KickoffErrors(t *testing.T) {
+	s := newTestServer(t)
+	home, away := s.team("Barcelona"), s.team("Real Madrid")
+
+	tests := []struct {
+		name  string
+		body  map[string]any
+		code  string
+		field string
+	}{
+		{"sin fecha ni hora de inicio", map[string]any{}, codeValidationFailed, "date"},
+		{"hora de inicio sin zona horaria", map[string]any{"kickoff": "2025-04-01T21:00:00"}, codeInvalidDate, "kickoff"},
+		{"hora de inicio sin hora", map[string]any{"kickoff": "2025-04-01"}, codeInvalidDate, "kickoff"},
+		{"fecha distinta de la hora de inicio", map[string]any{"date": "2025-04-02", "kickoff": "2025-04-01T21:00:00+02:00"}, codeValidationFailed, "date"},
+		{"fecha UTC de la hora de inicio", map[string]any{"date": "2025-04-01", "kickoff": "2025-04-02T00:30:00+02:00"}, codeValidationFailed, "date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.body["homeTeamId"], tt.body["awayTeamId"] = home.ID, away.ID
+			p := expectProblem(t, s.do(http.MethodPost, "/api/v2/matches", tt.body), http.StatusBadRequest, tt.code)
+			if len(p.Errors) != 1 || p.Errors[0].Field != tt.field {
+				t.Errorf("errores %+v, se esperaba el campo %s", p.Errors, tt.field)
+			}
+		})
+	}
+}
+
+func TestReplaceMatchV2Kickoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    map[string]any
+		kickoff any
+	}{
+		{"nueva hora de inicio", map[string]any{"kickoff": "2025-04-01T18:30:00Z"}, "2025-04-01T18:30:00Z"},
+		{"misma fecha sin hora de inicio", map[string]any{"date": "2025-04-01"}, "2025-04-01T19:00:00Z"},
+		{"otra fecha sin hora de inicio", map[string]any{"date": "2025-04-08"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			home, away := s.team("Barcelona"), s.team("Real Madrid")
+			kickoff := time.Date(2025, 4, 1, 19, 0, 0, 0, time.UTC)
+			m, err := s.store.CreateMatch(t.Context(), MatchInput{HomeTeamID: home.ID, AwayTeamID: away.ID, MatchDate: kickoff.Truncate(24 * time.Hour), Kickoff: &kickoff})
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.body["homeTeamId"], tt.body["awayTeamId"] = home.ID, away.ID
+
+			rec := s.do(http.MethodPut, fmt.Sprintf("/api/v2/matches/%d", m.ID), tt.body)
+			expectStatus(t, rec, http.StatusOK)
+			if got := decode[map[string]any](t, rec)["kickoff"]; got != tt.kickoff {
+				t.Errorf("kickoff %v, se esperaba %v", got, tt.kickoff)
+			}
+		})
+	}
+}
+
+func TestV1DeprecationHeaders(t *testing.T) {
+	s := newTestServer(t)
+	rec := s.do(http.MethodGet, "/api/matches", nil)
+	expectStatus(t, rec, http.StatusOK)
+	if got := rec.Header().Get("Deprecation"); got != "@1792281600" {
+		t.Errorf("Deprecation %q, se esperaba @1792281600 (2026-10-18)", got)
+	}
+	if got := rec.Header().Get("Sunset"); got != "Wed, 30 Jun 2027 00:00:00 GMT" {
+		t.Errorf("Sunset %q, se esperaba el 30 de junio de 2027", got)
+	}
+
+	rec = s.do(http.MethodGet, "/api/v2/matches", nil)
+	if rec.Header().Get("Deprecation") != "" || rec.Header().Get("Sunset") != "" {
+		t.Errorf("v2 incluye cabeceras de retirada: %v", rec.Header())
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "example/Lab06/docs"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// versionSettings define la retirada de las rutas de partidos de /api, que
+// reemplaza /api/v2. Una fecha cero no envía la cabecera correspondiente.
+type versionSettings struct {
+	V1Deprecation time.Time // desde cuándo v1 está obsoleta
+	V1Sunset      time.Time // cuándo v1 dejará de responder
+}
+
+// deprecatedV1 agrega las cabeceras Deprecation (RFC 9745) y Sunset
+// (RFC 8594) a las respuestas de las rutas de v1
+func (s versionSettings) deprecatedV1() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !s.V1Deprecation.IsZero() {
+			c.Header("Deprecation", "@"+strconv.FormatInt(s.V1Deprecation.Unix(), 10))
+		}
+		if !s.V1Sunset.IsZero() {
+			c.Header("Sunset", s.V1Sunset.UTC().Format(http.TimeFormat))
+		}
+		c.Next()
+	}
+}
+
+// swaggerDocs sirve un documento Swagger por versión de la API en
+// /swagger/v1/ y /swagger/v2/. Cualquier otra ruta redirige a v1.
+//
+// Cada versión usa su propio manejador de archivos porque gin-swagger fija el
+// prefijo de la ruta con la primera petición.
+func swaggerDocs() gin.HandlerFunc {
+	handlers := map[string]gin.HandlerFunc{}
+	for _, version := range []string{"v1", "v2"} {
+		handlers[version] = ginSwagger.WrapHandler(swaggerFiles.NewHandler(), ginSwagger.InstanceName(version))
+	}
+	return func(c *gin.Context) {
+		version, _, _ := strings.Cut(strings.TrimPrefix(c.Param("any"), "/"), "/")
+		if h, ok := handlers[version]; ok {
+			h(c)
+			return
+		}
+		c.Redirect(http.StatusFound, "/swagger/v1/index.html")
+	}
+}